@@ -2,13 +2,19 @@
 package app
 
 import (
+	"context"
 	"embed"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/idelchi/godyl/internal/cli"
 	"github.com/idelchi/godyl/internal/cli/core"
 )
 
 // Execute runs the root command.
+// An interrupt or termination signal cancels the in-flight work, allowing it to clean up after itself.
+// A second signal terminates the application immediately.
 func Execute(version string, files embed.FS) error {
 	// Get the embedded files
 	embedded, err := core.NewEmbeddedFiles(files)
@@ -16,8 +22,17 @@ func Execute(version string, files embed.FS) error {
 		return err
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Restore the default behavior after the first signal, so that a second one terminates.
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	// Execute the application
-	if err := cli.Command(embedded, version).Execute(); err != nil {
+	if err := cli.Command(embedded, version).ExecuteContext(ctx); err != nil {
 		return err
 	}
 
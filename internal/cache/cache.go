@@ -2,6 +2,8 @@
 package cache
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,15 +12,19 @@ import (
 
 	"github.com/idelchi/godyl/internal/debug"
 	"github.com/idelchi/godyl/internal/tools/version"
+	"github.com/idelchi/godyl/pkg/flock"
 	"github.com/idelchi/godyl/pkg/path/file"
 	"github.com/idelchi/godyl/pkg/wildcard"
 )
 
 // New creates a new cache manager with the specified file as the backend.
+// The file is guarded against concurrent writes of other processes by a lock file next to it.
 func New(file file.File) *Cache {
 	return &Cache{
-		File:  file,
-		items: make(Items),
+		File:    file,
+		items:   make(Items),
+		changes: make(Items),
+		lock:    flock.New(file + ".lock"),
 	}
 }
 
@@ -44,6 +50,12 @@ type Item struct {
 
 	// Updated is the time when the item was last updated.
 	Updated time.Time `json:"updated"`
+
+	// Hash is the SHA-256 hash of the installed file, to detect changes made to it since.
+	Hash string `json:"hash,omitempty"`
+
+	// Size is the size of the installed file in bytes.
+	Size int64 `json:"size,omitempty"`
 }
 
 // ErrItemNotFound is returned when an item is not found in the cache.
@@ -63,10 +75,16 @@ func (i Items) AsSlice() []*Item {
 }
 
 // Cache is a cache backend that stores data in a JSON file.
+//
+// Several processes may share the file. Each write merges the changes made by this process
+// into the current contents of the file while holding a lock, and replaces the file atomically.
 type Cache struct {
 	file.File // embedded file.File for cache operations
 
 	items      Items        // tracked items in the cache
+	changes    Items        // items changed since the last write, nil for deleted items
+	cleared    bool         // indicator if all items were deleted since the last write
+	lock       *flock.Lock  // lock guarding the file against other processes
 	mu         sync.RWMutex // mutex for concurrent access
 	wasTouched bool         // indicator if the cache was modified
 }
@@ -81,22 +99,23 @@ func (c *Cache) IsEmpty() bool {
 
 // Load creates or loads the cache file.
 func (c *Cache) Load() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Load existing cache data if the file exists
 	if c.Exists() {
-		return c.load()
-	}
+		items, err := c.load()
+		if err != nil {
+			return err
+		}
 
-	// Otherwise, create a new cache file
-	if err := c.Create(); err != nil {
-		return err //nolint:wrapcheck 	// Error does not need additional wrapping.
-	}
+		c.items = items
 
-	// Initialize the cache with an empty array
-	if err := c.Write([]byte("[]")); err != nil {
-		return err //nolint:wrapcheck 	// Error does not need additional wrapping.
+		return nil
 	}
 
-	return nil
+	// Otherwise, create a new cache file, unless another process just did
+	return c.write()
 }
 
 // Get retrieves items from the cache by ID.
@@ -158,7 +177,7 @@ func (c *Cache) Delete(identifiers ...string) error {
 
 	if len(identifiers) == 0 {
 		// If no identifiers are provided, delete all items
-		c.items = make(Items)
+		c.clear()
 
 		return c.persist()
 	}
@@ -206,7 +225,7 @@ func (c *Cache) DeleteByName(names ...string) error {
 
 	if len(names) == 0 {
 		// If no names are provided, delete all items
-		c.items = make(Items)
+		c.clear()
 
 		return c.persist()
 	}
@@ -228,6 +247,8 @@ func (c *Cache) deleteByName(name string) error {
 		if item.Name == name {
 			delete(c.items, id)
 
+			c.changes[id] = nil
+
 			return c.persist()
 		}
 	}
@@ -259,9 +280,10 @@ func (c *Cache) getByName(name string) (*Item, error) {
 	return nil, fmt.Errorf("%w: %q", ErrItemNotFound, name)
 }
 
-// Save stores an item in the cache.
+// add stores an item in the cache.
 func (c *Cache) add(item *Item) error {
 	c.items[item.ID] = item
+	c.changes[item.ID] = item
 
 	return c.persist()
 }
@@ -274,49 +296,100 @@ func (c *Cache) delete(identifier string) error {
 
 	delete(c.items, identifier)
 
+	c.changes[identifier] = nil
+
 	return c.persist()
 }
 
+// clear removes all items from the cache, including the ones added by other processes.
+func (c *Cache) clear() {
+	c.items = make(Items)
+	c.changes = make(Items)
+	c.cleared = true
+}
+
 // load reads the cache data from disk.
-func (c *Cache) load() error {
-	file, err := c.Open()
+// A missing or empty file holds no items.
+func (c *Cache) load() (Items, error) {
+	items := make(Items)
+
+	if !c.Exists() {
+		return items, nil
+	}
+
+	data, err := c.Read()
 	if err != nil {
-		return err //nolint:wrapcheck 	// Error does not need additional wrapping.
+		return nil, err //nolint:wrapcheck 	// Error does not need additional wrapping.
 	}
-	defer file.Close()
 
-	var items []*Item
+	if len(bytes.TrimSpace(data)) == 0 {
+		return items, nil
+	}
 
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&items); err != nil {
-		return err //nolint:wrapcheck 	// Error does not need additional wrapping.
+	var list []*Item
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decoding cache %q: %w", c.Path(), err)
 	}
 
-	c.items = make(map[string]*Item, len(items))
-	for _, item := range items {
-		c.items[item.ID] = item
+	for _, item := range list {
+		items[item.ID] = item
 	}
 
-	return nil
+	return items, nil
 }
 
-// persist writes the cache data to disk.
+// persist writes the changes to disk and marks the cache as modified.
 func (c *Cache) persist() error {
-	file, err := c.OpenForWriting()
-	if err != nil {
-		return err //nolint:wrapcheck 	// Error does not need additional wrapping.
+	if err := c.write(); err != nil {
+		return err
 	}
-	defer file.Close()
 
-	items := make([]*Item, 0, len(c.items))
-	for _, item := range c.items {
-		items = append(items, item)
+	c.wasTouched = true
+
+	return nil
+}
+
+// write merges the changes into the cache data on disk and writes it back.
+// The lock is held while reading and writing, such that changes of other processes are not lost.
+func (c *Cache) write() (err error) {
+	if err := c.lock.Lock(context.Background()); err != nil {
+		return fmt.Errorf("locking cache: %w", err)
 	}
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
+	defer func() {
+		err = errors.Join(err, c.lock.Unlock())
+	}()
 
-	c.wasTouched = true
+	items := make(Items)
+
+	if !c.cleared {
+		if items, err = c.load(); err != nil {
+			return err
+		}
+	}
 
-	return encoder.Encode(items) //nolint:wrapcheck 	// Error does not need additional wrapping.
+	for id, item := range c.changes {
+		if item == nil {
+			delete(items, id)
+		} else {
+			items[id] = item
+		}
+	}
+
+	list := items.AsSlice()
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding cache: %w", err)
+	}
+
+	if err := c.WriteAtomic(append(data, '\n')); err != nil {
+		return err //nolint:wrapcheck 	// Error does not need additional wrapping.
+	}
+
+	c.items = items
+	c.changes = make(Items)
+	c.cleared = false
+
+	return nil
 }
@@ -283,6 +283,58 @@ func TestCachePersistence(t *testing.T) {
 	}
 }
 
+func TestCacheMergesConcurrentWriters(t *testing.T) {
+	t.Parallel()
+
+	f := file.New(t.TempDir(), "test-cache.json")
+
+	// Two caches loaded before either writes, as two processes sharing the file would be.
+	c1, c2 := cache.New(f), cache.New(f)
+
+	for _, c := range []*cache.Cache{c1, c2} {
+		if err := c.Load(); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+	}
+
+	if err := c1.Add(testItem("id1", "owner/one"), testItem("id3", "owner/three")); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	if err := c2.Add(testItem("id2", "owner/two")); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	if err := c2.Delete("id2"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if err := c1.Add(testItem("id2", "owner/two")); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	reloaded := cache.New(f)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	items, err := reloaded.Get()
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	slices.Sort(ids)
+
+	if diff := cmp.Diff([]string{"id1", "id2", "id3"}, ids); diff != "" {
+		t.Errorf("merged items mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestCacheTouched(t *testing.T) {
 	t.Parallel()
 
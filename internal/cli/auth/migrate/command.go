@@ -0,0 +1,48 @@
+// Package migrate contains the subcommand definition for `auth migrate`.
+package migrate
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/auth/migrate"
+	"github.com/idelchi/godyl/internal/config/root"
+)
+
+// Command returns the `auth migrate` command.
+func Command(global *root.Config, local any) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move authentication tokens between token stores.",
+		Long: heredoc.Doc(`
+			Move all tokens, including the per-host tokens, from one token store to another.
+			The tokens are removed from the source store once they have been stored in the target store.
+
+			The passphrase of the token file is read from GODYL_TOKEN_PASSPHRASE,
+			or prompted for when attached to a terminal.
+		`),
+		Example: heredoc.Doc(`
+			# Move the tokens from the keyring to the encrypted token file
+			$ godyl auth migrate --from keyring --to file
+
+			# Move the tokens back into the keyring
+			$ godyl auth migrate --from file --to keyring
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Exit early if the command is run with `--show/-s` flag.
+			if core.ExitOnShow(global.ShowFunc) {
+				return nil
+			}
+
+			return run(core.Input{Global: global, Embedded: nil, Cmd: cmd, Args: args})
+		},
+	}
+
+	core.SetSubcommandDefaults(cmd, local, global.ShowFunc)
+
+	migrate.Flags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,44 @@
+package migrate
+
+import (
+	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/iutils"
+)
+
+// run executes the `auth migrate` command.
+func run(input core.Input) error {
+	cfg, _, _, _, _ := input.Unpack()
+
+	logger, err := core.SetupLogger(cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+
+	from := core.NewTokenStore(cfg.Auth.Migrate.From, cfg)
+	to := core.NewTokenStore(cfg.Auth.Migrate.To, cfg)
+
+	if ok, err := from.Available(); !ok {
+		return err
+	}
+
+	if ok, err := to.Available(); !ok {
+		return err
+	}
+
+	keys, _ := iutils.StructToKoanf(cfg.Tokens)
+
+	moved, err := from.MoveTo(to, keys.Keys()...)
+	if err != nil {
+		return err
+	}
+
+	if len(moved) == 0 {
+		logger.Infof("No tokens found in the %s.", from)
+
+		return nil
+	}
+
+	logger.Infof("Tokens %q successfully moved from the %s to the %s.", moved, from, to)
+
+	return nil
+}
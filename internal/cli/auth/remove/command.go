@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/auth/remove"
 	"github.com/idelchi/godyl/internal/config/root"
 	"github.com/idelchi/godyl/internal/iutils"
 	"github.com/idelchi/godyl/pkg/pretty"
@@ -22,6 +23,7 @@ func Command(global *root.Config, local any) *cobra.Command {
 		Short: "Remove authentication tokens.",
 		Long: heredoc.Docf(`
 			Remove all or the specified tokens, either in the configuration file or in the keyring.
+			Use --host to remove the tokens stored for specific hosts.
 
 			Allowed values are:
 
@@ -33,6 +35,9 @@ func Command(global *root.Config, local any) *cobra.Command {
 
 			# Remove only the GitLab token
 			$ godyl --keyring auth remove gitlab-token
+
+			# Remove the token for a host
+			$ godyl --keyring auth remove --host github.example.com
 		`),
 		Aliases:   []string{"rm"},
 		Args:      cobra.OnlyValidArgs,
@@ -49,5 +54,7 @@ func Command(global *root.Config, local any) *cobra.Command {
 
 	core.SetSubcommandDefaults(cmd, local, global.ShowFunc)
 
+	remove.Flags(cmd)
+
 	return cmd
 }
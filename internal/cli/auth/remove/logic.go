@@ -5,13 +5,12 @@ import (
 
 	"github.com/idelchi/godyl/internal/cli/core"
 	"github.com/idelchi/godyl/internal/iutils"
-	"github.com/idelchi/godyl/internal/tokenstore"
 	"github.com/idelchi/godyl/pkg/editor"
 )
 
 // run executes the `auth remove` command.
 //
-//nolint:gocognit // Complex function - refactoring into smaller functions is a separate improvement task
+//nolint:gocognit,gocyclo,cyclop,funlen // Complex function - refactoring into smaller functions is a separate improvement task
 func run(input core.Input) error {
 	cfg, _, context, _, args := input.Unpack()
 
@@ -20,41 +19,73 @@ func run(input core.Input) error {
 		return err
 	}
 
+	hosts := cfg.Auth.Remove.Hosts
+
+	// Without any selection, all tokens are removed
+	all := len(args) == 0 && len(hosts) == 0
+
 	var errs []error
 
 	switch cfg.Keyring {
 	case true:
-		store := tokenstore.New()
+		store := core.TokenStore(cfg)
 		if ok, err := store.Available(); !ok {
 			return err
 		}
 
-		if len(args) == 0 {
+		if all {
 			if err := store.Delete(); err != nil {
 				return err
 			}
 
-			logger.Info("All tokens successfully deleted from the keyring.")
+			logger.Infof("All tokens successfully deleted from the %s.", store)
 		}
 
 		for _, key := range args {
 			if err := store.Delete(key); err != nil {
-				logger.Warnf("%s: secret not found in keyring", key)
+				logger.Warnf("%s: secret not found in %s", key, store)
 
 				continue
 			}
 
-			logger.Infof("Token %q successfully deleted from the keyring.", key)
+			logger.Infof("Token %q successfully deleted from the %s.", key, store)
+		}
+
+		if len(hosts) > 0 {
+			stored, err := store.GetHosts()
+			if err != nil {
+				return err
+			}
+
+			for _, host := range hosts {
+				if _, ok := stored.Get(host); !ok {
+					logger.Warnf("%s: no token for host found in %s", host, store)
+
+					continue
+				}
+
+				stored = stored.Without(host)
+
+				logger.Infof("Token for host %q successfully deleted from the %s.", host, store)
+			}
+
+			if err := store.SetHosts(stored); err != nil {
+				return err
+			}
 		}
 	case false:
 		configuration := context.Config
 
 		tokens, _ := iutils.StructToKoanf(cfg.Tokens)
 
-		keys := tokens.Keys()
+		keys := args
+
+		if all {
+			keys = tokens.Keys()
 
-		if len(args) > 0 {
-			keys = args
+			if configuration.Exists("host-tokens") {
+				keys = append(keys, "host-tokens")
+			}
 		}
 
 		for _, key := range keys {
@@ -72,6 +103,33 @@ func run(input core.Input) error {
 
 			logger.Infof("Token %q successfully deleted from the configuration file %q.", key, cfg.ConfigFile)
 		}
+
+		if len(hosts) > 0 {
+			stored := cfg.Hosts
+
+			for _, host := range hosts {
+				if _, ok := stored.Get(host); !ok {
+					logger.Warnf("%s: no token for host found in configuration file %q", host, cfg.ConfigFile)
+
+					continue
+				}
+
+				stored = stored.Without(host)
+
+				logger.Infof("Token for host %q successfully deleted from the configuration file %q.", host, cfg.ConfigFile)
+			}
+
+			data := configuration.Map()
+			delete(data, "host-tokens")
+
+			if len(stored) > 0 {
+				data["host-tokens"] = stored
+			}
+
+			if err := editor.New(cfg.ConfigFile).Write(data); err != nil {
+				return err
+			}
+		}
 	}
 
 	return errors.Join(errs...)
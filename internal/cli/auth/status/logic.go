@@ -11,7 +11,7 @@ import (
 
 // run executes the `auth status` command.
 func run(input core.Input) error {
-	cfg, _, _, _, _ := input.Unpack()
+	cfg, _, context, _, _ := input.Unpack()
 
 	kTokens, _ := iutils.StructToKoanf(cfg.Tokens)
 
@@ -19,7 +19,7 @@ func run(input core.Input) error {
 
 	if !slices.ContainsFunc(slices.Collect(maps.Values(tokens)), func(v any) bool {
 		return v != ""
-	}) {
+	}) && len(cfg.Hosts) == 0 {
 		fmt.Println("No authentication tokens are set in the current configuration.")
 
 		return nil
@@ -30,10 +30,16 @@ func run(input core.Input) error {
 
 		if value == "" {
 			set = "unset"
+		} else if origin, ok := context.TokenOrigins[key]; ok {
+			set += fmt.Sprintf(" (%s)", origin)
 		}
 
 		fmt.Printf("%s: %s\n", key, set)
 	}
 
+	for _, host := range cfg.Hosts.Hosts() {
+		fmt.Printf("host-tokens[%s]: set\n", host)
+	}
+
 	return nil
 }
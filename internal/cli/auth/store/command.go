@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/auth/store"
 	"github.com/idelchi/godyl/internal/config/root"
 	"github.com/idelchi/godyl/internal/iutils"
 	"github.com/idelchi/godyl/pkg/pretty"
@@ -22,6 +23,8 @@ func Command(global *root.Config, local any) *cobra.Command {
 		Short: "Store authentication tokens.",
 		Long: heredoc.Docf(`
 			Store all or the specified tokens, either in the configuration file or in the keyring.
+			With --host, the single selected token is stored for the given host (and optional path prefix),
+			and used for all requests to it.
 
 			Allowed values are:
 
@@ -39,6 +42,12 @@ func Command(global *root.Config, local any) *cobra.Command {
 
 			# Store the GitHub token in the keyring, using an environment variable
 			$ GODYL_GITHUB_TOKEN=token godyl auth store github-token
+
+			# Store a token for a GitHub Enterprise Server in the keyring
+			$ GODYL_GITHUB_TOKEN=token godyl --keyring auth store github-token --host github.example.com
+
+			# Store a token for a group on a self-hosted GitLab
+			$ GODYL_GITLAB_TOKEN=token godyl auth store gitlab-token --host gitlab.example.com/group
 		`),
 		Args:      cobra.OnlyValidArgs,
 		ValidArgs: tokens.Keys(),
@@ -54,5 +63,7 @@ func Command(global *root.Config, local any) *cobra.Command {
 
 	core.SetSubcommandDefaults(cmd, local, global.ShowFunc)
 
+	store.Flags(cmd)
+
 	return cmd
 }
@@ -2,12 +2,15 @@ package store
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/root"
 	"github.com/idelchi/godyl/internal/ierrors"
 	"github.com/idelchi/godyl/internal/iutils"
-	"github.com/idelchi/godyl/internal/tokenstore"
 	"github.com/idelchi/godyl/pkg/editor"
+	"github.com/idelchi/godyl/pkg/logger"
 )
 
 // run executes the `auth store` command.
@@ -42,9 +45,13 @@ func run(input core.Input) error {
 		return err
 	}
 
+	if host := cfg.Auth.Store.Host; host != "" {
+		return storeHost(cfg, logger, host, selected)
+	}
+
 	switch cfg.Keyring {
 	case true:
-		store := tokenstore.New()
+		store := core.TokenStore(cfg)
 
 		if ok, err := store.Available(); !ok {
 			return err
@@ -55,7 +62,7 @@ func run(input core.Input) error {
 			return err
 		}
 
-		logger.Info("tokens successfully set in the keyring.")
+		logger.Infof("tokens successfully set in the %s.", store)
 	case false:
 		if err := editor.New(cfg.ConfigFile).Merge(selected); err != nil {
 			return err
@@ -66,3 +73,52 @@ func run(input core.Input) error {
 
 	return nil
 }
+
+// storeHost stores the value of the single selected token for the host.
+func storeHost(cfg *root.Config, logger *logger.Logger, host string, selected map[string]any) error {
+	if len(selected) != 1 {
+		return fmt.Errorf(
+			"%w: select exactly one token to store for host %q, got %v",
+			ierrors.ErrUsage,
+			host,
+			slices.Sorted(maps.Keys(selected)),
+		)
+	}
+
+	var token string
+
+	for key, value := range selected {
+		var ok bool
+		if token, ok = value.(string); !ok {
+			return fmt.Errorf("%s: invalid token type %T, expected string", key, value)
+		}
+	}
+
+	switch cfg.Keyring {
+	case true:
+		store := core.TokenStore(cfg)
+
+		if ok, err := store.Available(); !ok {
+			return err
+		}
+
+		hosts, err := store.GetHosts()
+		if err != nil {
+			return err
+		}
+
+		if err := store.SetHosts(hosts.With(host, token)); err != nil {
+			return err
+		}
+
+		logger.Infof("token for host %q successfully set in the %s.", host, store)
+	case false:
+		if err := editor.New(cfg.ConfigFile).Merge(map[string]any{"host-tokens": cfg.Hosts.With(host, token)}); err != nil {
+			return err
+		}
+
+		logger.Infof("token for host %q successfully set in the configuration file.", host)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/idelchi/godyl/internal/cli/auth/migrate"
 	"github.com/idelchi/godyl/internal/cli/auth/remove"
 	"github.com/idelchi/godyl/internal/cli/auth/status"
 	"github.com/idelchi/godyl/internal/cli/auth/store"
@@ -12,8 +13,9 @@ import (
 // subcommands for the `auth` command.
 func subcommands(cmd *cobra.Command, global *root.Config) {
 	cmd.AddCommand(
-		remove.Command(global, nil),
-		store.Command(global, nil),
+		remove.Command(global, &global.Auth.Remove),
+		store.Command(global, &global.Auth.Store),
 		status.Command(global, nil),
+		migrate.Command(global, &global.Auth.Migrate),
 	)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/cache/clean"
 	"github.com/idelchi/godyl/internal/config/root"
 )
 
@@ -17,6 +18,18 @@ func Command(global *root.Config, local any) *cobra.Command {
 		Long: heredoc.Doc(`
 			Clean can be run to clear the cache from removed tools,
 			as well as updating the recorded versions in case of mismatches.
+
+			With --metadata and/or --archives, the cached release metadata and/or downloads are purged instead.
+		`),
+		Example: heredoc.Doc(`
+			# Clean the cache from removed tools
+			$ godyl cache clean
+
+			# Purge the cached release metadata
+			$ godyl cache clean --metadata
+
+			# Purge the cached downloads
+			$ godyl cache clean --archives
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -31,5 +44,7 @@ func Command(global *root.Config, local any) *cobra.Command {
 
 	core.SetSubcommandDefaults(cmd, local, global.ShowFunc)
 
+	clean.Flags(cmd)
+
 	return cmd
 }
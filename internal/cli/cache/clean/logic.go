@@ -4,18 +4,26 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dustin/go-humanize"
+
 	"github.com/idelchi/godyl/internal/cache"
 	"github.com/idelchi/godyl/internal/cli/core"
 	"github.com/idelchi/godyl/internal/config/root"
 	"github.com/idelchi/godyl/internal/data"
+	"github.com/idelchi/godyl/internal/history"
 	"github.com/idelchi/godyl/pkg/executable"
 	"github.com/idelchi/godyl/pkg/logger"
+	"github.com/idelchi/godyl/pkg/path/folder"
 	"github.com/idelchi/godyl/pkg/version"
 )
 
 // run executes the `cache clean` command.
 func run(input core.Input) error {
-	cfg, _, _, _, _ := input.Unpack()
+	cfg, _, _, cmd, _ := input.Unpack()
+
+	if clean := cfg.Caching.Clean; clean.Metadata || clean.Archives {
+		return purge(cfg, clean.Metadata, clean.Archives)
+	}
 
 	logger, cacheHandler, err := setup(cfg)
 	if err != nil {
@@ -27,8 +35,12 @@ func run(input core.Input) error {
 		return fmt.Errorf("getting tools from cache: %w", err)
 	}
 
+	changes := history.New(data.HistoryFile(cfg.Cache.Dir))
+
 	for _, tool := range tools {
-		cleanTool(cacheHandler, tool, logger)
+		if cleanTool(cacheHandler, tool, logger) {
+			recordPruned(changes, tool, cmd.Root().Version, logger)
+		}
 	}
 
 	if !cacheHandler.Touched() {
@@ -38,6 +50,45 @@ func run(input core.Input) error {
 	return nil
 }
 
+// purge removes the cached release metadata and/or downloads.
+func purge(cfg *root.Config, metadata, archives bool) error {
+	logger, err := core.SetupLogger(cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+
+	var dirs []folder.Folder
+
+	if metadata {
+		dirs = append(dirs, data.MetadataDir(cfg.Cache.Dir))
+	}
+
+	if archives {
+		dirs = append(dirs, data.ArchivesDir(cfg.Cache.Dir))
+	}
+
+	for _, dir := range dirs {
+		if !dir.Exists() {
+			logger.Infof("nothing to purge in %q", dir)
+
+			continue
+		}
+
+		size, err := dir.Size()
+		if err != nil {
+			return fmt.Errorf("determining size of %q: %w", dir, err)
+		}
+
+		if err := dir.Remove(); err != nil {
+			return fmt.Errorf("purging %q: %w", dir, err)
+		}
+
+		logger.Infof("purged %s from %q", humanize.Bytes(uint64(size)), dir)
+	}
+
+	return nil
+}
+
 // setup initializes the logger and cache handler.
 func setup(cfg *root.Config) (*logger.Logger, *cache.Cache, error) {
 	logger, err := core.SetupLogger(cfg.LogLevel)
@@ -60,21 +111,24 @@ func setup(cfg *root.Config) (*logger.Logger, *cache.Cache, error) {
 }
 
 // cleanTool removes missing tools from cache or updates version for existing tools.
-func cleanTool(cacheHandler *cache.Cache, tool *cache.Item, logger *logger.Logger) {
+// Returns true if the tool was removed from the cache.
+func cleanTool(cacheHandler *cache.Cache, tool *cache.Item, logger *logger.Logger) bool {
 	exe := executable.New(tool.Path)
 
 	if !exe.ToFile().Exists() {
 		if err := cacheHandler.Delete(tool.ID); err != nil {
 			logger.Warnf("failed to delete cache for id %q: %v", tool.ID, err)
-		} else {
-			logger.Warnf("cache deleted for %q: executable %q has been removed from system", tool.Name, tool.Path)
+
+			return false
 		}
 
-		return
+		logger.Warnf("cache deleted for %q: executable %q has been removed from system", tool.Name, tool.Path)
+
+		return true
 	}
 
 	if tool.Version.Commands == nil {
-		return
+		return false
 	}
 
 	parser := &executable.Parser{
@@ -86,11 +140,11 @@ func cleanTool(cacheHandler *cache.Cache, tool *cache.Item, logger *logger.Logge
 	if err != nil {
 		logger.Warnf("failed to parse version for %q: %v", tool.Name, err)
 
-		return
+		return false
 	}
 
 	if version.Equal(parsed, tool.Version.Version) {
-		return
+		return false
 	}
 
 	tool.Version.Version = parsed
@@ -101,4 +155,23 @@ func cleanTool(cacheHandler *cache.Cache, tool *cache.Item, logger *logger.Logge
 	} else {
 		logger.Infof("cache updated for %q: version %q parsed", tool.Name, tool.Version.Version)
 	}
+
+	return false
+}
+
+// recordPruned records the removal of the tool from the cache in the history,
+// as its executable was found missing.
+func recordPruned(changes *history.History, tool *cache.Item, godyl string, logger *logger.Logger) {
+	if err := changes.Append(history.Entry{
+		Tool:    tool.Name,
+		Action:  history.Pruned,
+		From:    tool.Version.Version,
+		Path:    tool.Path,
+		Source:  tool.Type,
+		Godyl:   godyl,
+		Outcome: "ok",
+		Message: "executable found missing, removed from the cache",
+	}); err != nil {
+		logger.Warnf("failed to record removal of %q in history: %v", tool.Name, err)
+	}
 }
@@ -14,6 +14,6 @@ func subcommands(cmd *cobra.Command, global *root.Config) {
 	cmd.AddCommand(
 		path.Command(global, nil),
 		remove.Command(global, nil),
-		clean.Command(global, nil),
+		clean.Command(global, &global.Caching.Clean),
 	)
 }
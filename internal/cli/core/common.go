@@ -2,6 +2,8 @@ package core
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/idelchi/godyl/internal/config/root"
 	"github.com/idelchi/godyl/internal/defaults"
@@ -109,20 +111,26 @@ func (c *Handler) Logger() *logger.Logger {
 
 // SetupLogger creates and configures a logger with the specified log level.
 func (c *Handler) SetupLogger(level string) (err error) {
-	c.logger, err = SetupLogger(level)
+	c.logger, err = SetupLogger(level, c.config.LogOutput())
 
 	return err
 }
 
-// SetupLogger creates a new logger instance with the specified log level.
-func SetupLogger(level string) (*logger.Logger, error) {
+// SetupLogger creates a new logger instance with the specified log level,
+// writing to the standard output unless another output is provided.
+func SetupLogger(level string, output ...io.Writer) (*logger.Logger, error) {
 	// Retrieve log level and create a logger instance
 	lvl, err := logger.LevelString(level)
 	if err != nil {
 		return nil, fmt.Errorf("parsing log level: %w", err)
 	}
 
-	l, err := logger.New(lvl)
+	var w io.Writer = os.Stdout
+	if len(output) > 0 {
+		w = output[0]
+	}
+
+	l, err := logger.NewCustom(lvl, w)
 	if err != nil {
 		return nil, fmt.Errorf("creating logger: %w", err)
 	}
@@ -19,4 +19,6 @@ type Context struct {
 	DotEnv *env.Env
 	// Env holds the parsed environment variables.
 	Env *env.Env
+	// TokenOrigins holds where each of the set tokens was taken from.
+	TokenOrigins map[string]string
 }
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+
+	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/tokenstore"
+	"github.com/idelchi/godyl/pkg/stdin"
+)
+
+// PassphraseEnv is the environment variable holding the passphrase of the token file.
+const PassphraseEnv = "GODYL_TOKEN_PASSPHRASE"
+
+// ErrNoPassphrase is returned when the passphrase of the token file can not be obtained.
+var ErrNoPassphrase = errors.New("no passphrase for the token file")
+
+// TokenStore returns the token store selected in the configuration.
+func TokenStore(cfg *root.Config) tokenstore.TokenStore {
+	return NewTokenStore(cfg.TokenStore, cfg)
+}
+
+// NewTokenStore returns the token store for the given backend.
+func NewTokenStore(backend string, cfg *root.Config) tokenstore.TokenStore {
+	if backend == "file" {
+		return tokenstore.NewFile(cfg.TokenFile.Path(), Passphrase)
+	}
+
+	return tokenstore.New()
+}
+
+// Passphrase returns the passphrase of the token file from the environment,
+// or prompts for it when attached to a terminal.
+// The passphrase is only obtained once per process.
+var Passphrase = sync.OnceValues(passphrase)
+
+// passphrase obtains the passphrase of the token file.
+func passphrase() (string, error) {
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	if !stdin.IsInteractive() {
+		return "", fmt.Errorf("%w: set %s", ErrNoPassphrase, PassphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "Token file passphrase: ")
+
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+
+	if len(passphrase) == 0 {
+		return "", ErrNoPassphrase
+	}
+
+	return string(passphrase), nil
+}
@@ -1,6 +1,7 @@
 package download
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/idelchi/godyl/internal/cli/core"
@@ -21,7 +22,7 @@ import (
 
 // run executes the `download` command.
 func run(input core.Input) error {
-	cfg, embedded, _, _, args := input.Unpack()
+	cfg, embedded, _, cmd, args := input.Unpack()
 
 	if cfg.Download.Dry {
 		cfg.Verbose = 1
@@ -80,7 +81,7 @@ func run(input core.Input) error {
 
 	proc.NoDownload = cfg.Download.Dry
 
-	summary, err := proc.Process(tags.IncludeTags{})
+	summary, err := proc.Process(cmd.Context(), tags.IncludeTags{})
 	if err != nil {
 		return fmt.Errorf("processing tools: %w", err)
 	}
@@ -90,5 +91,10 @@ func run(input core.Input) error {
 		ErrorFile: cfg.ErrorFile,
 	}, runner.Logger())
 
-	return summary.Error()
+	reports, err := cfg.Reports()
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(presentation.WriteReports(summary, reports), summary.Error())
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/dump/auth"
 	"github.com/idelchi/godyl/internal/config/root"
 )
 
@@ -14,9 +15,11 @@ func Command(global *root.Config, local any) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "auth",
 		Short: "Display authentication tokens.",
+		Long:  "Display authentication tokens, masked unless --unmask is given.",
 		Example: heredoc.Doc(`
 			$ godyl dump auth
 			$ godyl --keyring dump auth
+			$ godyl --keyring dump auth --unmask
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -30,5 +33,7 @@ func Command(global *root.Config, local any) *cobra.Command {
 	}
 	core.SetSubcommandDefaults(cmd, local, global.ShowFunc)
 
+	auth.Flags(cmd)
+
 	return cmd
 }
@@ -5,46 +5,99 @@ import (
 
 	"github.com/idelchi/godyl/internal/cli/core"
 	"github.com/idelchi/godyl/internal/iutils"
-	"github.com/idelchi/godyl/internal/tokenstore"
+	"github.com/idelchi/godyl/pkg/credentials"
 	"github.com/idelchi/godyl/pkg/pretty"
 )
 
+// tokens holds the tokens to display, masked unless requested otherwise.
+type tokens struct {
+	GitHub  string             `mask:"fixed" yaml:"github-token,omitempty"`
+	GitLab  string             `mask:"fixed" yaml:"gitlab-token,omitempty"`
+	URL     string             `mask:"fixed" yaml:"url-token,omitempty"`
+	Hosts   credentials.Tokens `yaml:"host-tokens,omitempty"`
+	Origins map[string]string  `yaml:"origins,omitempty"`
+}
+
 // run executes the `dump auth` command.
 func run(input core.Input) error {
 	cfg, _, context, _, _ := input.Unpack()
 
-	tokens, _ := iutils.StructToKoanf(cfg.Tokens)
+	keys, _ := iutils.StructToKoanf(cfg.Tokens)
 
-	var output any
+	var output tokens
 
 	switch cfg.Keyring {
 	case true:
-		store := tokenstore.New()
+		store := core.TokenStore(cfg)
 
 		if ok, err := store.Available(); !ok {
 			return err
 		}
 
-		values, err := store.GetAll(tokens.Keys()...)
+		values, err := store.GetAll(keys.Keys()...)
+		if err != nil {
+			return err
+		}
+
+		hosts, err := store.GetHosts()
 		if err != nil {
 			return err
 		}
 
-		if len(values) == 0 {
-			fmt.Println("No tokens found in the keyring.")
+		if len(values) == 0 && len(hosts) == 0 && len(context.TokenOrigins) == 0 {
+			fmt.Printf("No tokens found in the %s.\n", store)
 
 			return nil
 		}
 
-		output = values
-
+		output = tokens{
+			GitHub: values["github-token"],
+			GitLab: values["gitlab-token"],
+			URL:    values["url-token"],
+			Hosts:  hosts,
+		}
 	case false:
-		configuration := context.Config.Filtered(tokens.Keys()...)
+		configuration := context.Config
 
-		output = configuration.Map()
+		output = tokens{
+			GitHub: configuration.String("github-token"),
+			GitLab: configuration.String("gitlab-token"),
+			URL:    configuration.String("url-token"),
+		}
+
+		if configuration.Exists("host-tokens") {
+			output.Hosts = cfg.Hosts
+		}
+	}
+
+	output.Origins = context.TokenOrigins
+
+	if !cfg.Dump.Auth.Unmask {
+		output = output.masked()
 	}
 
 	pretty.PrintYAML(output)
 
 	return nil
 }
+
+// masked returns a copy of the tokens with all non-empty values masked.
+func (t tokens) masked() tokens {
+	masked, ok := pretty.MaskJSON(t).(tokens)
+	if !ok {
+		return tokens{}
+	}
+
+	// Keep unset tokens empty, to have them omitted
+	for _, field := range []struct{ original, masked *string }{
+		{&t.GitHub, &masked.GitHub},
+		{&t.GitLab, &masked.GitLab},
+		{&t.URL, &masked.URL},
+	} {
+		if *field.original == "" {
+			*field.masked = ""
+		}
+	}
+
+	return masked
+}
@@ -23,6 +23,6 @@ func subcommands(cmd *cobra.Command, global *root.Config, embedded *core.Embedde
 		tools.Command(global, &global.Dump.Tools, embedded),
 		cache.Command(global, nil),
 		cconfig.Command(global, nil),
-		auth.Command(global, nil),
+		auth.Command(global, &global.Dump.Auth),
 	)
 }
@@ -0,0 +1,54 @@
+// Package history contains the subcommand definition for `history`.
+package history
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/history"
+	"github.com/idelchi/godyl/internal/config/root"
+)
+
+// Command returns the `history` command.
+func Command(global *root.Config, local any) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [tool...]",
+		Short: "Display the history of installed, updated and pruned tools",
+		Long: heredoc.Doc(`
+			Display the history of the changes made to the tools, oldest first.
+			Tools can be given as patterns, with '*' matching any characters.
+
+			The recorded actions are:
+			  install  a tool was installed where it did not exist
+			  update   an existing tool was replaced
+			  pruned   'cache clean' found the executable missing and removed the tool from the cache,
+			           recorded for whoever ran 'cache clean', not whoever removed the executable
+		`),
+		Example: heredoc.Doc(`
+			# Show the full history
+			$ godyl history
+
+			# Show who changed jq during the last week
+			$ godyl history jq --since 168h
+
+			# Show the last 10 failed changes as JSON
+			$ godyl history --outcome failed,timed_out -n 10 --format json
+		`),
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Exit early if the command is run with `--show/-s` flag.
+			if core.ExitOnShow(global.ShowFunc) {
+				return nil
+			}
+
+			return run(core.Input{Global: global, Cmd: cmd, Args: args})
+		},
+	}
+
+	core.SetSubcommandDefaults(cmd, local, global.ShowFunc)
+
+	history.Flags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,103 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/data"
+	"github.com/idelchi/godyl/internal/history"
+	"github.com/idelchi/godyl/pkg/pretty"
+)
+
+// run executes the `history` command.
+func run(input core.Input) error {
+	cfg, _, _, _, args := input.Unpack()
+
+	entries, err := history.New(data.HistoryFile(cfg.Cache.Dir)).Read()
+	if err != nil {
+		return fmt.Errorf("reading history: %w", err)
+	}
+
+	filter := history.Filter{
+		Tools:    args,
+		Outcomes: cfg.History.Outcome,
+		Limit:    cfg.History.Limit,
+	}
+
+	for _, action := range cfg.History.Action {
+		filter.Actions = append(filter.Actions, history.Action(action))
+	}
+
+	if cfg.History.Since > 0 {
+		filter.Since = time.Now().Add(-cfg.History.Since)
+	}
+
+	entries = filter.Apply(entries)
+
+	if cfg.History.Format == "json" {
+		if entries == nil {
+			entries = []history.Entry{}
+		}
+
+		pretty.PrintJSON(entries)
+
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No changes recorded.")
+
+		return nil
+	}
+
+	fmt.Println(render(entries))
+
+	return nil
+}
+
+// render renders the entries as a table.
+func render(entries []history.Entry) string {
+	t := table.NewWriter()
+
+	t.SetStyle(table.StyleRounded)
+	t.Style().Color.Header = text.Colors{text.FgBlue, text.Bold}
+
+	t.AppendHeader(table.Row{"Time", "Tool", "Action", "Version", "Outcome", "User", "Godyl"})
+
+	for _, entry := range entries {
+		version := entry.To
+
+		switch {
+		case entry.Action == history.Pruned:
+			version = entry.From
+		case entry.From != "" && entry.From != entry.To:
+			version = entry.From + " → " + entry.To
+		}
+
+		user := entry.User
+		if entry.Host != "" {
+			user += "@" + entry.Host
+		}
+
+		colors := text.Colors{text.FgGreen}
+		if entry.Outcome != "ok" {
+			colors = text.Colors{text.FgRed}
+		}
+
+		t.AppendRow(table.Row{
+			entry.Time.Local().Format(time.DateTime),
+			entry.Tool,
+			entry.Action,
+			version,
+			colors.Sprint(entry.Outcome),
+			user,
+			entry.Godyl,
+		})
+	}
+
+	return t.Render()
+}
@@ -1,6 +1,7 @@
 package install
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/idelchi/godyl/internal/cli/core"
@@ -13,7 +14,7 @@ import (
 
 // run executes the `install` command.
 func run(input core.Input) error {
-	cfg, embedded, _, _, args := input.Unpack()
+	cfg, embedded, _, cmd, args := input.Unpack()
 
 	if cfg.Install.Dry {
 		cfg.Verbose = 1
@@ -48,8 +49,9 @@ func run(input core.Input) error {
 	proc := processor.New(tools, *cfg, runner.Logger())
 
 	proc.NoDownload = cfg.Install.Dry
+	proc.Version = cmd.Root().Version
 
-	summary, err := proc.Process(iutils.SplitTags(cfg.Install.Tags))
+	summary, err := proc.Process(cmd.Context(), iutils.SplitTags(cfg.Install.Tags))
 	if err != nil {
 		return fmt.Errorf("processing tools: %w", err)
 	}
@@ -59,5 +61,10 @@ func run(input core.Input) error {
 		ErrorFile: cfg.ErrorFile,
 	}, runner.Logger())
 
-	return summary.Error()
+	reports, err := cfg.Reports()
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(presentation.WriteReports(summary, reports), summary.Error())
 }
@@ -15,10 +15,16 @@ import (
 
 	"github.com/idelchi/godyl/internal/cli/core"
 	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/data"
 	"github.com/idelchi/godyl/internal/debug"
+	"github.com/idelchi/godyl/internal/github"
+	"github.com/idelchi/godyl/internal/ierrors"
 	"github.com/idelchi/godyl/internal/iutils"
-	"github.com/idelchi/godyl/internal/tokenstore"
+	"github.com/idelchi/godyl/internal/metadata"
+	"github.com/idelchi/godyl/internal/mirrors"
+	"github.com/idelchi/godyl/internal/network"
 	"github.com/idelchi/godyl/pkg/cobraext"
+	"github.com/idelchi/godyl/pkg/credentials"
 	penv "github.com/idelchi/godyl/pkg/env"
 	"github.com/idelchi/godyl/pkg/koanfx"
 	"github.com/idelchi/godyl/pkg/logger"
@@ -231,68 +237,85 @@ func run(cmd *cobra.Command, cfg *root.Config, calledFrom *cobra.Command) error
 
 	// 4th Pass
 	// Default values for tokens are deferred such that they can be
-	// set with .env files or the keyring without unnecessary checks
+	// set with .env files or the token store without unnecessary checks
 
 	// TODO(Idelchi): Allow also GITHUB_TOKEN_FILE, GITLAB_TOKEN_FILE, URL_TOKEN_FILE
-	githubToken := menv.GetAny("GITHUB_TOKEN", "GH_TOKEN")
-	gitlabToken := menv.GetAny("GITLAB_TOKEN", "CI_JOB_TOKEN")
-	urlToken := menv.GetAny("URL_TOKEN")
+	defaults := []tokenDefault{
+		{key: "github-token", host: "github.com", env: []string{"GITHUB_TOKEN", "GH_TOKEN"}},
+		{key: "gitlab-token", host: "gitlab.com", env: []string{"GITLAB_TOKEN", "CI_JOB_TOKEN"}},
+		{key: "url-token", env: []string{"URL_TOKEN"}},
+	}
 
-	if !cfg.AllTokensSet() && cfg.Keyring {
-		commandPath := calledFrom.CommandPath()
-		if !strings.HasPrefix(commandPath, "godyl auth store") {
-			store := tokenstore.New()
+	// Per-host tokens from the token store, which are overridden by the ones in the configuration
+	var storedHosts credentials.Tokens
 
-			if ok, err := store.Available(); !ok {
-				return err
-			}
+	if cfg.Keyring && !slices.ContainsFunc([]string{"godyl auth store", "godyl auth migrate"}, func(prefix string) bool {
+		return strings.HasPrefix(calledFrom.CommandPath(), prefix)
+	}) {
+		store := core.TokenStore(cfg)
 
-			ghToken, _ := store.Get("github-token")
-			glToken, _ := store.Get("gitlab-token")
-			uToken, _ := store.Get("url-token")
+		if ok, err := store.Available(); !ok {
+			return err
+		}
 
-			githubToken = iutils.Any(ghToken, githubToken)
-			gitlabToken = iutils.Any(glToken, gitlabToken)
-			urlToken = iutils.Any(uToken, urlToken)
+		if !cfg.AllTokensSet() {
+			for i := range defaults {
+				token, _ := store.Get(defaults[i].key)
+				defaults[i].offer(token, store.String())
+			}
 		}
-	}
 
-	if err := cobraext.SetFlagIfNotSet(flags.Lookup("github-token"), githubToken); err != nil {
-		return err
-	}
+		hosts, err := store.GetHosts()
+		if err != nil {
+			return fmt.Errorf("retrieving tokens from %s: %w", store, err)
+		}
 
-	if err := cobraext.SetFlagIfNotSet(flags.Lookup("gitlab-token"), gitlabToken); err != nil {
-		return err
+		storedHosts = hosts
 	}
 
-	if err := cobraext.SetFlagIfNotSet(flags.Lookup("url-token"), urlToken); err != nil {
-		return err
+	origins := make(map[string]string, len(defaults))
+
+	for i := range defaults {
+		def := &defaults[i]
+
+		if cfg.IsSet(def.key) {
+			origins[def.key] = "configuration"
+
+			continue
+		}
+
+		def.offer(menv.GetAny(def.env...), "environment")
+
+		// Only query the credential helpers when no other token was found, as they may be slow
+		if def.value == "" && def.host != "" && len(cfg.TokenChain) > 0 {
+			if token, helper, ok := credentials.Fill(cfg.TokenChain, def.host); ok {
+				def.offer(token, fmt.Sprintf("%s for %s", helper, def.host))
+			}
+		}
+
+		if err := cobraext.SetFlagIfNotSet(flags.Lookup(def.key), def.value); err != nil {
+			return err
+		}
+
+		if def.value != "" {
+			origins[def.key] = def.origin
+		}
 	}
 
+	core.GlobalContext.TokenOrigins = origins
+
 	// Parse again with the new defaults
 	if err := core.KCreateSubcommandPreRunE(cmd, cfg, root.NoShow)(cmd, []string{}); err != nil {
 		return err
 	}
 
-	if cfg.Tokens.GitHub == "" && UsesAPI(calledFrom) {
-		if !k.Tracker.IsSet("parallel") {
-			if err := cobraext.SetFlagIfNotSet(flags.Lookup("parallel"), "1"); err != nil {
-				return err
-			}
+	cfg.Hosts = storedHosts.MergedWith(cfg.Hosts)
 
-			logWarning = append(
-				logWarning,
-				"GitHub token is not set. Limiting parallelism to 1 to avoid hitting rate limits.",
-			)
-		} else {
-			logWarning = append(
-				logWarning,
-				fmt.Sprintf(
-					"GitHub token is not set. Make sure you don't hit rate limits with current level: %v",
-					cfg.Parallel,
-				),
-			)
-		}
+	if !cfg.HasGitHubToken() && UsesAPI(calledFrom) {
+		logWarning = append(
+			logWarning,
+			"GitHub token is not set. GitHub API requests are sent one at a time and are subject to lower rate limits.",
+		)
 	}
 
 	// Parse last time
@@ -300,13 +323,39 @@ func run(cmd *cobra.Command, cfg *root.Config, calledFrom *cobra.Command) error
 		return err
 	}
 
+	// Share the transport with the configured certificate authorities, proxies, timeouts and retries by all requests
+	if err := network.Configure(cfg.Network.Options(), cfg.Network.Retries, cfg.Network.RetryWait); err != nil {
+		return fmt.Errorf("%w: %w", ierrors.ErrUsage, err)
+	}
+
+	// Apply the rate limit policy to the GitHub API requests of all tools
+	github.Scheduler.SetPolicy(cfg.RateLimitPolicy)
+
+	// Rewrite the URLs of all downloads to their mirrors, compiling the rules once for all of them
+	rules, err := cfg.Mirrors.Compiled()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ierrors.ErrUsage, err)
+	}
+
+	mirrors.Set(rules)
+
+	// Cache the release metadata of all tools, unless caching is disabled
+	if cfg.Cache.Disabled {
+		metadata.Disable()
+	} else {
+		metadata.Enable(data.MetadataDir(cfg.Cache.Dir), cfg.Cache.MetadataTTL)
+	}
+
+	// Re-apply the per-host tokens from the token store, as parsing may have replaced them with the configured ones
+	cfg.Hosts = storedHosts.MergedWith(cfg.Hosts)
+
 	// Full config available here
 	lvl, err := logger.LevelString(cfg.LogLevel)
 	if err != nil {
 		return fmt.Errorf("parsing log level: %w", err)
 	}
 
-	log, err := logger.New(lvl)
+	log, err := logger.NewCustom(lvl, cfg.LogOutput())
 	if err != nil {
 		return fmt.Errorf("creating logger: %w", err)
 	}
@@ -318,6 +367,26 @@ func run(cmd *cobra.Command, cfg *root.Config, calledFrom *cobra.Command) error
 	return nil
 }
 
+// tokenDefault is a deferred default value for a token, along with its origin.
+type tokenDefault struct {
+	// key is the name of the token flag
+	key string
+	// host is queried with the credential helpers, if any
+	host string
+	// env are the environment variables to fall back to
+	env []string
+
+	value  string
+	origin string
+}
+
+// offer sets the value and its origin, unless a value was already found.
+func (d *tokenDefault) offer(value, origin string) {
+	if d.value == "" && value != "" {
+		d.value, d.origin = value, origin
+	}
+}
+
 // UsesAPI returns true if the command called from uses any API tokens.
 func UsesAPI(calledFrom *cobra.Command) bool {
 	usesAPI := []string{
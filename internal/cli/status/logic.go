@@ -1,22 +1,28 @@
 package status
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/idelchi/godyl/internal/cache"
 	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/data"
 	"github.com/idelchi/godyl/internal/iutils"
 	"github.com/idelchi/godyl/internal/presentation"
 	"github.com/idelchi/godyl/internal/processor"
 	"github.com/idelchi/godyl/internal/tools"
+	"github.com/idelchi/godyl/internal/tools/drift"
+	"github.com/idelchi/godyl/internal/tools/strategy"
 	"github.com/idelchi/godyl/internal/tools/tool"
 	"github.com/idelchi/godyl/pkg/unmarshal"
 )
 
-// TODO(Idelchi): Presentation must look different for status (green -> yellow, yellow -> green, red -> red).
-
 // run executes the `status` command.
 func run(input core.Input) error {
-	cfg, embedded, _, _, args := input.Unpack()
+	cfg, embedded, _, cmd, args := input.Unpack()
 
 	// Always set the verbose level to 1 for the status command
 	cfg.Verbose = 1
@@ -46,21 +52,90 @@ func run(input core.Input) error {
 		return err
 	}
 
+	// Resolve the target version of every tool, regardless of whether it exists
+	for _, t := range tools {
+		t.Strategy = strategy.Sync
+	}
+
 	// At this point, all tools have been resolved and can be processed by the processor
 	proc := processor.New(tools, *cfg, runner.Logger())
 
 	proc.NoDownload = true
 	proc.Options = []tool.ResolveOption{tool.WithoutURL()}
 
-	summary, err := proc.Process(iutils.SplitTags(cfg.Status.Tags))
+	summary, err := proc.Process(cmd.Context(), iutils.SplitTags(cfg.Status.Tags))
 	if err != nil {
 		return fmt.Errorf("processing tools: %w", err)
 	}
 
-	presentation.ShowSummary(summary, presentation.ShowConfig{
+	// Compare the installed tools with the ones installed by godyl
+	installed, err := loadCache(cfg)
+	if err != nil {
+		return err
+	}
+
+	states := detect(drift.New(installed), summary)
+
+	presentation.ShowDrift(states, summary, presentation.ShowConfig{
 		Verbose:   cfg.Verbose,
 		ErrorFile: cfg.ErrorFile,
 	}, runner.Logger())
 
-	return summary.Error()
+	reports, err := cfg.Reports()
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(presentation.WriteReports(summary, reports), summary.Error(), failOn(states, cfg.Status.FailOn))
+}
+
+// loadCache loads the cache of the tools installed by godyl, if enabled.
+func loadCache(cfg *root.Config) (*cache.Cache, error) {
+	if cfg.Cache.Disabled {
+		return nil, nil //nolint:nilnil	// No cache to compare against when disabled.
+	}
+
+	installed := cache.New(data.CacheFile(cfg.Cache.Dir))
+
+	if err := installed.Load(); err != nil {
+		return nil, fmt.Errorf("loading cache: %w", err)
+	}
+
+	return installed, nil
+}
+
+// detect determines the states of the tools that were resolved, leaving out the failed and filtered ones.
+func detect(detector *drift.Detector, summary processor.Summary) []drift.Report {
+	var reports []drift.Report
+
+	for _, result := range summary.Results {
+		if result.Status != processor.StatusOK && result.Status != processor.StatusSkipped {
+			continue
+		}
+
+		if !result.Tool.Selected() {
+			continue
+		}
+
+		reports = append(reports, detector.Detect(result.Tool))
+	}
+
+	return reports
+}
+
+// failOn returns an error listing the tools in any of the states.
+func failOn(reports []drift.Report, states []string) error {
+	var failing []string
+
+	for _, report := range reports {
+		if slices.Contains(states, string(report.State)) {
+			failing = append(failing, fmt.Sprintf("%s (%s)", report.Tool, report.State))
+		}
+	}
+
+	if len(failing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d tools in failing states: %s", len(failing), strings.Join(failing, ", "))
 }
@@ -9,11 +9,13 @@ import (
 	"github.com/idelchi/godyl/internal/cli/core"
 	"github.com/idelchi/godyl/internal/cli/download"
 	"github.com/idelchi/godyl/internal/cli/dump"
+	"github.com/idelchi/godyl/internal/cli/history"
 	"github.com/idelchi/godyl/internal/cli/install"
 	"github.com/idelchi/godyl/internal/cli/paths"
 	"github.com/idelchi/godyl/internal/cli/status"
 	"github.com/idelchi/godyl/internal/cli/update"
 	"github.com/idelchi/godyl/internal/cli/validate"
+	"github.com/idelchi/godyl/internal/cli/verify"
 	"github.com/idelchi/godyl/internal/cli/version"
 	"github.com/idelchi/godyl/internal/config/root"
 )
@@ -24,13 +26,15 @@ func subcommands(cmd *cobra.Command, global *root.Config, embedded *core.Embedde
 		install.Command(global, &global.Install, embedded),
 		download.Command(global, &global.Download, embedded),
 		status.Command(global, &global.Status, embedded),
+		verify.Command(global, &global.Verify, embedded),
 		dump.Command(global, nil, embedded),
 		update.Command(global, &global.Update, embedded),
 		cache.Command(global, nil),
 		cconfig.Command(global, nil),
-		validate.Command(global, nil, embedded),
+		validate.Command(global, &global.Validation, embedded),
 		auth.Command(global, nil),
 		paths.Command(global, nil),
+		history.Command(global, &global.History),
 
 		version.Command(global, nil),
 	)
@@ -28,6 +28,12 @@ func Command(global *root.Config, local any, embedded *core.Embedded) *cobra.Com
 
 			Update to the latest version with cleanup (windows only):
 			$ godyl update --cleanup
+
+			Restore the binary from before the last update:
+			$ godyl update --rollback
+
+			Update from a GitHub Enterprise server, verifying the signature of the checksum file:
+			$ godyl update --server=https://github.example.com --repo=tools/godyl --public-key=godyl.pub
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Exit early if the command is run with `--show/-s` flag.
@@ -25,6 +25,10 @@ func run(input core.Input) error {
 		return fmt.Errorf("setting up logger: %w", err)
 	}
 
+	if cfg.Update.Rollback {
+		return updater.New(&godyl, nil, handler.Logger()).Rollback()
+	}
+
 	if err := handler.Resolve("", &tools.Tools{godyl.Tool}); err != nil {
 		return err
 	}
@@ -35,5 +39,5 @@ func run(input core.Input) error {
 
 	updater := updater.New(&godyl, embedded.Template, handler.Logger())
 
-	return updater.Update(cfg.Update.Check)
+	return updater.Update(cmd.Context(), cfg.Update.Check)
 }
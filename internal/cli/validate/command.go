@@ -7,26 +7,33 @@ import (
 
 	"github.com/idelchi/godyl/internal/cli/core"
 	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/config/validate"
 )
 
 // Command returns the `validate` command.
 func Command(global *root.Config, local any, embedded *core.Embedded) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "validate",
-		Short: "Validate the configuration for all subcommands",
-		Args:  cobra.NoArgs,
-		Run: func(cmd *cobra.Command, args []string) {
+		Use:   "validate [tools.yml|-]...",
+		Short: "Validate the configuration for all subcommands and lint the tools file(s)",
+		Long: "Validate the configuration for all subcommands and lint the tools file(s).\n" +
+			"Without arguments, the configured tools file is linted if it exists.",
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := run(core.Input{Global: global, Cmd: cmd, Args: args, Embedded: embedded}); err != nil {
-				color.Red(err.Error())
+				return err
+			}
 
-				return
+			if global.Validation.Format == "text" {
+				color.Green("Validation passed!")
 			}
 
-			color.Green("Validation passed!")
+			return nil
 		},
 	}
 
 	core.SetSubcommandDefaults(cmd, local, global.ShowFunc)
 
+	validate.Flags(cmd)
+
 	return cmd
 }
@@ -4,11 +4,24 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/config/shared"
+	"github.com/idelchi/godyl/internal/defaults"
+	"github.com/idelchi/godyl/internal/iutils"
+	"github.com/idelchi/godyl/internal/lint"
+	"github.com/idelchi/godyl/internal/tools"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/pretty"
+	"github.com/idelchi/godyl/pkg/unmarshal"
 )
 
+// ErrFindings is returned when findings at or above the configured severity remain.
+var ErrFindings = errors.New("validation failed")
+
 // allSubCommands returns all subcommands (recursive) of the given root command.
 func allSubCommands(cmd *cobra.Command) []*cobra.Command {
 	var cmds []*cobra.Command
@@ -29,21 +42,175 @@ func allSubCommands(cmd *cobra.Command) []*cobra.Command {
 
 // run executes the `validate` command.
 func run(input core.Input) error {
-	_, _, _, cmd, _ := input.Unpack()
+	cfg, embedded, _, cmd, args := input.Unpack()
+
+	findings := validateCommands(cmd)
+
+	// Lint the tools file(s) given as arguments, or the configured one if it exists
+	paths := args
+	if len(paths) == 0 && file.New(cfg.Tools).Exists() {
+		paths = []string{cfg.Tools}
+	}
+
+	for _, path := range paths {
+		found, err := lintFile(*cfg, embedded, path)
+		if err != nil {
+			found = append(found, lint.Finding{
+				Rule:     "config",
+				Severity: lint.Error,
+				Message:  err.Error(),
+				Index:    -1,
+			})
+		}
+
+		for i := range found {
+			found[i].File = path
+		}
+
+		findings = append(findings, found...)
+	}
+
+	findings.Sort()
+
+	report(cfg.Validation.Format, findings)
+
+	failOn, err := lint.SeverityString(cfg.Validation.FailOn)
+	if err != nil {
+		return fmt.Errorf("parsing fail-on: %w", err)
+	}
+
+	if failed := findings.Unfixed().AtLeast(failOn); len(failed) > 0 {
+		return fmt.Errorf("%w: %d finding(s) with severity %q or higher", ErrFindings, len(failed), failOn)
+	}
 
-	all := allSubCommands(cmd.Root())
+	return nil
+}
 
-	errs := []error{}
+// validateCommands validates the configuration of all subcommands, reporting failures as findings.
+func validateCommands(cmd *cobra.Command) lint.Findings {
+	var findings lint.Findings
 
-	for _, sub := range all {
+	for _, sub := range allSubCommands(cmd.Root()) {
 		if sub.PersistentPreRunE == nil {
 			continue
 		}
 
 		if err := sub.PersistentPreRunE(sub, nil); err != nil {
-			errs = append(errs, fmt.Errorf("validating command %q: %w", cmd.CommandPath(), err))
+			findings = append(findings, lint.Finding{
+				Rule:     "config",
+				Severity: lint.Error,
+				Message:  fmt.Sprintf("validating command %q: %v", sub.CommandPath(), err),
+				Index:    -1,
+			})
 		}
 	}
 
-	return errors.Join(errs...)
+	return findings
+}
+
+// lintFile runs all lint rules on a single tools file, fixing the trivial findings if requested.
+// It works on a copy of the configuration, such that the settings of one file do not carry over to the next.
+func lintFile(cfg root.Config, embedded *core.Embedded, path string) (lint.Findings, error) {
+	data, err := iutils.ReadPaths(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", path, err)
+	}
+
+	// The tools are unmarshalled twice, as resolving them mutates them in place
+	var raw, resolved tools.Tools
+
+	if err := unmarshal.Strict(data, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshalling tools: %w", err)
+	}
+
+	if err := unmarshal.Strict(data, &resolved); err != nil {
+		return nil, fmt.Errorf("unmarshalling tools: %w", err)
+	}
+
+	cfg.Common = shared.Common{Tracker: cfg.Validation.Tracker}
+
+	if err := core.NewHandler(cfg, *embedded).Resolve(cfg.Defaults, &resolved); err != nil {
+		return nil, fmt.Errorf("resolving tools: %w", err)
+	}
+
+	in := lint.Input{
+		Raw:      raw,
+		Resolved: resolved,
+		Inherit:  cfg.Inherit,
+	}
+
+	// Only user provided defaults are checked for usage
+	if cfg.Defaults != "" && cfg.Defaults.Exists() {
+		content, err := cfg.Defaults.Read()
+		if err != nil {
+			return nil, fmt.Errorf("reading defaults file: %w", err)
+		}
+
+		if in.Defaults, err = defaults.NewDefaultsFromBytes(content); err != nil {
+			return nil, fmt.Errorf("loading defaults: %w", err)
+		}
+	}
+
+	findings := lint.Run(in)
+
+	if !cfg.Validation.Fix || path == "-" {
+		return findings, nil
+	}
+
+	fixedData, findings, err := lint.Fix(data, findings)
+	if err != nil {
+		return findings, fmt.Errorf("fixing %q: %w", path, err)
+	}
+
+	if len(findings) == len(findings.Unfixed()) {
+		return findings, nil
+	}
+
+	if err := file.New(path).Write(fixedData); err != nil {
+		return findings, fmt.Errorf("writing %q: %w", path, err)
+	}
+
+	return findings, nil
+}
+
+// report prints the findings in the given format.
+func report(format string, findings lint.Findings) {
+	if format == "json" {
+		if findings == nil {
+			findings = lint.Findings{}
+		}
+
+		pretty.PrintJSON(findings)
+
+		return
+	}
+
+	colors := map[lint.Severity]func(format string, a ...any){
+		lint.Error:   color.Red,
+		lint.Warning: color.Yellow,
+		lint.Info:    color.Cyan,
+	}
+
+	for _, finding := range findings {
+		location := finding.File
+
+		if finding.Index >= 0 {
+			location = fmt.Sprintf("%s: tool #%d (%s)", location, finding.Index+1, finding.Tool)
+		}
+
+		if location != "" {
+			location += ": "
+		}
+
+		suffix := ""
+
+		switch {
+		case finding.Fixed:
+			suffix = " (fixed)"
+		case finding.Fixable:
+			suffix = " (fixable with --fix)"
+		}
+
+		colors[finding.Severity]("%-7s %s%s [%s]%s", finding.Severity, location, finding.Message, finding.Rule, suffix)
+	}
 }
@@ -0,0 +1,46 @@
+// Package verify contains the subcommand definition for `verify`.
+package verify
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/config/verify"
+)
+
+// Command returns the `verify` command.
+func Command(global *root.Config, local any, embedded *core.Embedded) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [tools.yml|-]...",
+		Short: "Verify installed tools against the checksums published for them",
+		Long: heredoc.Doc(`
+			Verify the installed tools as specified in the YAML file(s) against the checksums published for them.
+			The release of the installed version of each tool is resolved again, and its checksum compared with
+			the installed executable, or with the cached archive it was extracted from.
+		`),
+		Example: heredoc.Doc(`
+			# Verify all installed tools
+			$ godyl verify tools.yml
+
+			# Fail on tools that cannot be verified as well, with the outcomes as JSON
+			$ godyl verify tools.yml --strict --format json
+		`),
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Exit early if the command is run with `--show/-s` flag.
+			if core.ExitOnShow(global.ShowFunc) {
+				return nil
+			}
+
+			return run(core.Input{Global: global, Cmd: cmd, Args: args, Embedded: embedded})
+		},
+	}
+
+	core.SetSubcommandDefaults(cmd, local, global.ShowFunc)
+
+	verify.Flags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,147 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/idelchi/godyl/internal/cache"
+	"github.com/idelchi/godyl/internal/cli/core"
+	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/data"
+	"github.com/idelchi/godyl/internal/iutils"
+	"github.com/idelchi/godyl/internal/presentation"
+	"github.com/idelchi/godyl/internal/tools"
+	"github.com/idelchi/godyl/internal/tools/tags"
+	"github.com/idelchi/godyl/internal/tools/verify"
+	"github.com/idelchi/godyl/pkg/download"
+	"github.com/idelchi/godyl/pkg/pretty"
+	"github.com/idelchi/godyl/pkg/unmarshal"
+)
+
+// run executes the `verify` command.
+func run(input core.Input) error {
+	cfg, embedded, _, cmd, args := input.Unpack()
+
+	// Load the tools from the source as []byte
+	content, err := iutils.ReadPathsOrDefault("tools.yml", args...)
+	if err != nil {
+		return fmt.Errorf("reading arguments %v: %w", args, err)
+	}
+
+	// The tools can now be unmarshalled into a tools.Tools instance
+	var tools tools.Tools
+
+	if err := unmarshal.Strict(content, &tools); err != nil {
+		return fmt.Errorf("unmarshalling tools: %w", err)
+	}
+
+	// Generate a common configuration for the command
+	cfg.Common = cfg.Verify.ToCommon()
+
+	runner := core.NewHandler(*cfg, *embedded)
+	if err := runner.SetupLogger(cfg.LogLevel); err != nil {
+		return fmt.Errorf("setting up logger: %w", err)
+	}
+
+	if err := runner.Resolve(cfg.Defaults, &tools); err != nil {
+		return err
+	}
+
+	verifier, err := newVerifier(cfg)
+	if err != nil {
+		return err
+	}
+
+	reports := verifyAll(cmd.Context(), verifier, tools, iutils.SplitTags(cfg.Verify.Tags), cfg.Parallel)
+
+	if cfg.Verify.Format == "json" {
+		if reports == nil {
+			reports = []verify.Report{}
+		}
+
+		pretty.PrintJSON(reports)
+	} else {
+		presentation.ShowVerify(reports, runner.Logger())
+	}
+
+	return failing(reports, cfg.Verify.Strict)
+}
+
+// newVerifier returns a verifier using the cache and the archive cache, if enabled.
+// Archives are looked up whether or not new downloads are stored in the archive cache.
+func newVerifier(cfg *root.Config) (*verify.Verifier, error) {
+	archives := download.NewArchiveCache(data.ArchivesDir(cfg.Cache.Dir), 0)
+
+	if cfg.Cache.Disabled {
+		return verify.New(nil, archives), nil
+	}
+
+	installed := cache.New(data.CacheFile(cfg.Cache.Dir))
+
+	if err := installed.Load(); err != nil {
+		return nil, fmt.Errorf("loading cache: %w", err)
+	}
+
+	return verify.New(installed, archives), nil
+}
+
+// verifyAll verifies the installed tools concurrently, with at most parallel tools at once if positive.
+func verifyAll(
+	ctx context.Context,
+	verifier *verify.Verifier,
+	tools tools.Tools,
+	tags tags.IncludeTags,
+	parallel int,
+) []verify.Report {
+	reports := make([]verify.Report, len(tools))
+	installed := make([]bool, len(tools))
+
+	var g errgroup.Group
+
+	if parallel > 0 {
+		g.SetLimit(parallel)
+	}
+
+	for i, t := range tools {
+		g.Go(func() error {
+			reports[i], installed[i] = verifier.Verify(ctx, t, tags)
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	var verified []verify.Report
+
+	for i, report := range reports {
+		if installed[i] {
+			verified = append(verified, report)
+		}
+	}
+
+	return verified
+}
+
+// failing returns an error listing the tools that failed verification, and those that could not be verified if strict.
+func failing(reports []verify.Report, strict bool) error {
+	var failed []string
+
+	for _, report := range reports {
+		if report.Outcome == verify.Fail || (strict && report.Outcome == verify.Unverifiable) {
+			failed = append(failed, fmt.Sprintf("%s (%s)", report.Tool, report.Outcome))
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	slices.Sort(failed)
+
+	return fmt.Errorf("%d tools failed verification: %s", len(failed), strings.Join(failed, ", "))
+}
@@ -0,0 +1,25 @@
+// Package auth provides the configuration for the `auth` command.
+package auth
+
+import (
+	"github.com/idelchi/godyl/internal/config/auth/migrate"
+	"github.com/idelchi/godyl/internal/config/auth/remove"
+	"github.com/idelchi/godyl/internal/config/auth/store"
+	"github.com/idelchi/godyl/internal/config/shared"
+)
+
+// Auth holds the configuration for the `auth` command.
+type Auth struct {
+	// Tracker embed the common tracker configuration, allowing to tracker
+	// whether configuration values have been explicitly set or defaulted
+	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// Store contains the configuration for the `godyl auth store` command.
+	Store store.Store `mapstructure:"store" validate:"-" yaml:"store"`
+
+	// Remove contains the configuration for the `godyl auth remove` command.
+	Remove remove.Remove `mapstructure:"remove" validate:"-" yaml:"remove"`
+
+	// Migrate contains the configuration for the `godyl auth migrate` command.
+	Migrate migrate.Migrate `mapstructure:"migrate" validate:"-" yaml:"migrate"`
+}
@@ -0,0 +1,17 @@
+// Package migrate provides configuration and flags for the `godyl auth migrate` command.
+package migrate
+
+import "github.com/idelchi/godyl/internal/config/shared"
+
+// Migrate holds the configuration for the `auth migrate` subcommand.
+type Migrate struct {
+	// Tracker embed the common tracker configuration, allowing to tracker
+	// whether configuration values have been explicitly set or defaulted
+	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// From is the token store to move the tokens from
+	From string `mapstructure:"from" validate:"oneof=keyring file,nefield=To" yaml:"from"`
+
+	// To is the token store to move the tokens to
+	To string `mapstructure:"to" validate:"oneof=keyring file" yaml:"to"`
+}
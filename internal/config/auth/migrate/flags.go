@@ -0,0 +1,11 @@
+package migrate
+
+import "github.com/spf13/cobra"
+
+// Flags configures the command-line flags for the auth migrate command.
+func Flags(cmd *cobra.Command) {
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().String("from", "keyring", "Token store to move the tokens from (keyring, file)")
+	cmd.Flags().String("to", "file", "Token store to move the tokens to (keyring, file)")
+}
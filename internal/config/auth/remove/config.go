@@ -0,0 +1,14 @@
+// Package remove provides configuration and flags for the `godyl auth remove` command.
+package remove
+
+import "github.com/idelchi/godyl/internal/config/shared"
+
+// Remove holds the configuration for the `auth remove` subcommand.
+type Remove struct {
+	// Tracker embed the common tracker configuration, allowing to tracker
+	// whether configuration values have been explicitly set or defaulted
+	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// Hosts to remove the tokens for
+	Hosts []string `mapstructure:"host" yaml:"host"`
+}
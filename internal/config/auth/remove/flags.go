@@ -0,0 +1,10 @@
+package remove
+
+import "github.com/spf13/cobra"
+
+// Flags configures the command-line flags for the auth remove command.
+func Flags(cmd *cobra.Command) {
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringSlice("host", nil, "Remove the tokens for these hosts")
+}
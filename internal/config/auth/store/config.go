@@ -0,0 +1,14 @@
+// Package store provides configuration and flags for the `godyl auth store` command.
+package store
+
+import "github.com/idelchi/godyl/internal/config/shared"
+
+// Store holds the configuration for the `auth store` subcommand.
+type Store struct {
+	// Tracker embed the common tracker configuration, allowing to tracker
+	// whether configuration values have been explicitly set or defaulted
+	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// Host to store the token for, optionally followed by a path prefix
+	Host string `mapstructure:"host" yaml:"host"`
+}
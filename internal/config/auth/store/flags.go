@@ -0,0 +1,10 @@
+package store
+
+import "github.com/spf13/cobra"
+
+// Flags configures the command-line flags for the auth store command.
+func Flags(cmd *cobra.Command) {
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().String("host", "", "Store the token for this host (optionally followed by a path prefix)")
+}
@@ -0,0 +1,17 @@
+// Package clean provides configuration and flags for the `godyl cache clean` command.
+package clean
+
+import "github.com/idelchi/godyl/internal/config/shared"
+
+// Clean holds the configuration for the `cache clean` subcommand.
+type Clean struct {
+	// Tracker embed the common tracker configuration, allowing to tracker
+	// whether configuration values have been explicitly set or defaulted
+	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// Metadata purges the cached release metadata
+	Metadata bool `mapstructure:"metadata" yaml:"metadata"`
+
+	// Archives purges the cached downloads
+	Archives bool `mapstructure:"archives" yaml:"archives"`
+}
@@ -0,0 +1,11 @@
+package clean
+
+import "github.com/spf13/cobra"
+
+// Flags configures the command-line flags for the cache clean command.
+func Flags(cmd *cobra.Command) {
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().Bool("metadata", false, "Purge the cached release metadata")
+	cmd.Flags().Bool("archives", false, "Purge the cached downloads")
+}
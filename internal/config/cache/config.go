@@ -2,10 +2,14 @@
 package cache
 
 import (
+	"github.com/idelchi/godyl/internal/config/cache/clean"
 	"github.com/idelchi/godyl/internal/config/shared"
 )
 
 // Cache represents the configuration for cache-related commands.
 type Cache struct {
 	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// Clean contains the configuration for the `godyl cache clean` command.
+	Clean clean.Clean `mapstructure:"clean" validate:"-" yaml:"clean"`
 }
@@ -0,0 +1,16 @@
+// Package auth provides configuration for dumping authentication tokens.
+package auth
+
+import (
+	"github.com/idelchi/godyl/internal/config/shared"
+)
+
+// Auth holds the configuration for the `dump auth` subcommand.
+type Auth struct {
+	// Tracker embed the common tracker configuration, allowing to tracker
+	// whether configuration values have been explicitly set or defaulted
+	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// Unmask indicates whether to show the tokens in plain text
+	Unmask bool `mapstructure:"unmask" yaml:"unmask"`
+}
@@ -0,0 +1,10 @@
+package auth
+
+import "github.com/spf13/cobra"
+
+// Flags configures the command-line flags for the dump auth command.
+func Flags(cmd *cobra.Command) {
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().Bool("unmask", false, "Show the tokens in plain text")
+}
@@ -2,6 +2,7 @@
 package dump
 
 import (
+	"github.com/idelchi/godyl/internal/config/dump/auth"
 	"github.com/idelchi/godyl/internal/config/dump/tools"
 	"github.com/idelchi/godyl/internal/config/shared"
 )
@@ -14,4 +15,7 @@ type Dump struct {
 
 	// Tools contains the configuration for the `godyl dump tools` command.
 	Tools tools.Tools `mapstructure:"tools" validate:"-" yaml:"tools"`
+
+	// Auth contains the configuration for the `godyl dump auth` command.
+	Auth auth.Auth `mapstructure:"auth" validate:"-" yaml:"auth"`
 }
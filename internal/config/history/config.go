@@ -0,0 +1,30 @@
+// Package history provides configuration and flags for the `godyl history` command.
+package history
+
+import (
+	"time"
+
+	"github.com/idelchi/godyl/internal/config/shared"
+)
+
+// History represents the configuration for the `history` command.
+type History struct {
+	// Tracker embed the common tracker configuration, allowing to tracker
+	// whether configuration values have been explicitly set or defaulted
+	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// Format is the output format of the entries
+	Format string `mapstructure:"format" validate:"oneof=text json" yaml:"format"`
+
+	// Action filters the entries by the change made to the tools
+	Action []string `mapstructure:"action" validate:"dive,oneof=install update pruned" yaml:"action"`
+
+	// Outcome filters the entries by the outcome of the changes
+	Outcome []string `mapstructure:"outcome" validate:"dive,oneof=ok failed timed_out interrupted" yaml:"outcome"`
+
+	// Since filters the entries to the ones more recent than the duration
+	Since time.Duration `mapstructure:"since" yaml:"since"`
+
+	// Limit is the maximum number of most recent entries to show, 0 means all
+	Limit int `mapstructure:"limit" validate:"min=0" yaml:"limit"`
+}
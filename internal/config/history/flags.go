@@ -0,0 +1,14 @@
+package history
+
+import "github.com/spf13/cobra"
+
+// Flags adds the flags for the `godyl history` command to the provided Cobra command.
+func Flags(cmd *cobra.Command) {
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringSlice("action", nil, "Only show these changes (install, update, pruned)")
+	cmd.Flags().StringSlice("outcome", nil, "Only show changes with these outcomes (ok, failed, timed_out, interrupted)")
+	cmd.Flags().Duration("since", 0, "Only show changes more recent than the duration, 0 means all")
+	cmd.Flags().IntP("limit", "n", 0, "Maximum number of most recent changes to show, 0 means all")
+	cmd.Flags().String("format", "text", "Output format of the changes (text, json)")
+}
@@ -2,15 +2,48 @@
 package root
 
 import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/idelchi/godyl/internal/config/auth"
+	"github.com/idelchi/godyl/internal/config/cache"
 	"github.com/idelchi/godyl/internal/config/download"
 	"github.com/idelchi/godyl/internal/config/dump"
+	"github.com/idelchi/godyl/internal/config/history"
 	"github.com/idelchi/godyl/internal/config/install"
 	"github.com/idelchi/godyl/internal/config/shared"
 	"github.com/idelchi/godyl/internal/config/status"
 	"github.com/idelchi/godyl/internal/config/update"
+	"github.com/idelchi/godyl/internal/config/validate"
+	"github.com/idelchi/godyl/internal/config/verify"
 	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/pkg/credentials"
+	"github.com/idelchi/godyl/pkg/mirror"
 	"github.com/idelchi/godyl/pkg/path/file"
 	"github.com/idelchi/godyl/pkg/path/folder"
+	"github.com/idelchi/godyl/pkg/transport"
+)
+
+// Output formats of processing tools.
+const (
+	// OutputText renders the results for humans.
+	OutputText = "text"
+	// OutputNDJSON streams the lifecycle events of the tools as newline-delimited JSON.
+	OutputNDJSON = "ndjson"
+)
+
+// Formats of the reports of the results.
+const (
+	// ReportJUnit writes the results as JUnit XML.
+	ReportJUnit = "junit"
+	// ReportMarkdown writes the results as a Markdown table.
+	ReportMarkdown = "markdown"
 )
 
 // TODO(Idelchi): Change all to be .Config instead of .Dump, .Update, etc.
@@ -24,11 +57,14 @@ type Config struct {
 	shared.Tracker `mapstructure:"-" yaml:"-"`
 
 	/* Subcommands */
+	// Auth contains the configuration for the `godyl auth` command
+	Auth auth.Auth `mapstructure:"auth" validate:"-" yaml:"auth"`
+
 	// Dump contains the configuration for the `godyl dump` command
 	Dump dump.Dump `mapstructure:"dump" validate:"-" yaml:"dump"`
 
-	// Cache contains the configuration for the `godyl cache` command (empty)
-	// Cache cache.Cache `yaml:"-" mapstructure:"-" validate:"-"`
+	// Caching contains the configuration for the `godyl cache` command
+	Caching cache.Cache `mapstructure:"cache" validate:"-" yaml:"cache"`
 
 	// Config contains the configuration for the `godyl config` command (empty)
 	// Config root.Config `yaml:"-" mapstructure:"-" validate:"-"`
@@ -45,16 +81,35 @@ type Config struct {
 	// Install contains the configuration for the `godyl install` command
 	Install install.Install `mapstructure:"install" validate:"-" yaml:"install"`
 
+	// Validation contains the configuration for the `godyl validate` command
+	Validation validate.Validate `mapstructure:"validate" validate:"-" yaml:"validate"`
+
+	// History contains the configuration for the `godyl history` command
+	History history.History `mapstructure:"history" validate:"-" yaml:"history"`
+
+	// Verify contains the configuration for the `godyl verify` command
+	Verify verify.Verify `mapstructure:"verify" validate:"-" yaml:"verify"`
+
 	/* Flags */
 	// Tokens store authentication tokens for various sources
 	Tokens Tokens `mapstructure:",squash" yaml:",inline,flatten"`
 
+	// Hosts holds tokens keyed by host (and optionally a path prefix),
+	// taking precedence over the source specific tokens for matching requests
+	Hosts credentials.Tokens `mapstructure:"host-tokens" yaml:"host-tokens"`
+
+	// Mirrors rewrites the URLs to download from, such as to internal mirrors of the release assets
+	Mirrors mirror.Rules `mapstructure:"mirrors" yaml:"mirrors"`
+
 	// Inherit specifies the default scheme to inherit from when no scheme is specified
 	Inherit string `mapstructure:"inherit" yaml:"inherit"`
 
 	// ErrorFile specifies the file to log errors
 	ErrorFile file.File `mapstructure:"error-file" yaml:"error-file"`
 
+	// Report specifies the reports of the results to write, as format=path
+	Report []string `mapstructure:"report" yaml:"report"`
+
 	// Tools specifies the tools file to be used
 	Tools string `mapstructure:"tools" yaml:"tools"`
 
@@ -73,9 +128,18 @@ type Config struct {
 	// Cache holds the cache configuration options
 	Cache Cache `mapstructure:",squash" yaml:",inline,flatten"`
 
+	// Network holds the TLS and proxy configuration options
+	Network Network `mapstructure:",squash" yaml:",inline,flatten"`
+
 	// Parallel specifies the number of parallel operations
 	Parallel int `mapstructure:"parallel" validate:"gte=0" yaml:"parallel"`
 
+	// RateLimitPolicy specifies whether to wait for or fail on exhausted API rate limits
+	RateLimitPolicy string `mapstructure:"rate-limit-policy" validate:"oneof=wait fail" yaml:"rate-limit-policy"`
+
+	// Timeout limits the resolution, the download and the post-installation commands of each tool
+	Timeout time.Duration `mapstructure:"timeout" validate:"gte=0" yaml:"timeout"`
+
 	// Verbose specifies the verbosity level
 	Verbose int `mapstructure:"verbose" yaml:"verbose"`
 
@@ -88,12 +152,24 @@ type Config struct {
 	// NoProgress disables progress indicators
 	NoProgress bool `mapstructure:"no-progress" yaml:"no-progress"`
 
+	// OutputFormat is the format of the output of processing the tools
+	OutputFormat string `mapstructure:"output-format" validate:"oneof=text ndjson" yaml:"output-format"`
+
 	// NoVerifyChecksum disables checksum verification
 	NoVerifyChecksum bool `mapstructure:"no-verify-checksum" yaml:"no-verify-checksum"`
 
-	// Keyring enables the use of the keyring for retrieving tokens
+	// Keyring enables the use of the token store for retrieving tokens
 	Keyring bool `mapstructure:"keyring" yaml:"keyring"`
 
+	// TokenStore selects the backend of the token store
+	TokenStore string `mapstructure:"token-store" validate:"oneof=keyring file" yaml:"token-store"`
+
+	// TokenFile specifies the encrypted file used by the `file` token store
+	TokenFile file.File `mapstructure:"token-file" yaml:"token-file"`
+
+	// TokenChain lists the credential helpers to query for tokens that are not set otherwise
+	TokenChain []string `mapstructure:"token-chain" validate:"dive,oneof=git netrc" yaml:"token-chain"`
+
 	/* Other Options */
 	// Common contains a subset of common configuration options
 	Common shared.Common `mapstructure:"-" yaml:"-"`
@@ -106,6 +182,64 @@ type Cache struct {
 
 	// Disabled disables cache interaction
 	Disabled bool `mapstructure:"no-cache" yaml:"no-cache"`
+
+	// MetadataTTL is the duration cached release metadata is used without revalidation
+	MetadataTTL time.Duration `mapstructure:"metadata-ttl" validate:"gte=0" yaml:"metadata-ttl"`
+
+	// Archives enables caching of downloads verified against a checksum
+	Archives bool `mapstructure:"archive-cache" yaml:"archive-cache"`
+
+	// ArchivesSize is the maximum size of the archive cache, such as `1GB`
+	ArchivesSize string `mapstructure:"archive-cache-size" yaml:"archive-cache-size"`
+}
+
+// ArchivesLimit returns the maximum size of the archive cache in bytes.
+func (c Cache) ArchivesLimit() (int64, error) {
+	size, err := humanize.ParseBytes(c.ArchivesSize)
+	if err != nil {
+		return 0, fmt.Errorf("parsing archive cache size %q: %w", c.ArchivesSize, err)
+	}
+
+	return int64(min(size, math.MaxInt64)), nil
+}
+
+// Network holds the configuration options for the TLS and proxy settings of all requests.
+type Network struct {
+	// CAFile is a PEM bundle of certificate authorities to trust in addition to the system ones
+	CAFile file.File `mapstructure:"ca-file" yaml:"ca-file"`
+
+	// CADir is a directory of PEM files of certificate authorities to trust in addition to the system ones
+	CADir folder.Folder `mapstructure:"ca-dir" yaml:"ca-dir"`
+
+	// HTTPProxy is the proxy for all requests, taking precedence over `HTTP_PROXY` and `HTTPS_PROXY`
+	HTTPProxy string `mapstructure:"http-proxy" yaml:"http-proxy"`
+
+	// NoProxy lists the hosts to exclude from proxying, taking precedence over `NO_PROXY`
+	NoProxy string `mapstructure:"no-proxy" yaml:"no-proxy"`
+
+	// MaxConnsPerHost limits the connections per host, 0 means unlimited
+	MaxConnsPerHost int `mapstructure:"max-conns-per-host" validate:"gte=0" yaml:"max-conns-per-host"`
+
+	// Timeout limits the time to connect to a server and receive its response headers
+	Timeout time.Duration `mapstructure:"http-timeout" validate:"gte=0" yaml:"http-timeout"`
+
+	// Retries is the number of retries of requests failing with connection or server errors
+	Retries int `mapstructure:"http-retries" validate:"gte=0" yaml:"http-retries"`
+
+	// RetryWait is the initial wait between retries, doubled for each retry
+	RetryWait time.Duration `mapstructure:"http-retry-wait" validate:"gte=0" yaml:"http-retry-wait"`
+}
+
+// Options returns the options to build the transport of all requests with.
+func (n Network) Options() transport.Options {
+	return transport.Options{
+		CAFile:          n.CAFile.Expanded().Path(),
+		CADir:           n.CADir.Expanded().Path(),
+		Proxy:           n.HTTPProxy,
+		NoProxy:         n.NoProxy,
+		MaxConnsPerHost: n.MaxConnsPerHost,
+		Timeout:         n.Timeout,
+	}
 }
 
 // Tokens holds the configuration options for authentication tokens.
@@ -120,6 +254,45 @@ type Tokens struct {
 	URL string `mapstructure:"url-token" mask:"fixed" yaml:"url-token"`
 }
 
+// LogOutput returns the writer to log to, keeping the standard output free for the events streamed as NDJSON.
+func (c *Config) LogOutput() io.Writer {
+	if c.OutputFormat == OutputNDJSON {
+		return os.Stderr
+	}
+
+	return os.Stdout
+}
+
+// Reports returns the files to write the reports of the results to, keyed by their format.
+func (c *Config) Reports() (map[string]file.File, error) {
+	reports := make(map[string]file.File, len(c.Report))
+
+	for _, report := range c.Report {
+		format, path, ok := strings.Cut(report, "=")
+		if !ok || path == "" {
+			return nil, fmt.Errorf("invalid report %q: expected format=path", report)
+		}
+
+		switch format {
+		case ReportJUnit, ReportMarkdown:
+		default:
+			return nil, fmt.Errorf("invalid report %q: unknown format %q, expected %q or %q",
+				report, format, ReportJUnit, ReportMarkdown)
+		}
+
+		reports[format] = file.New(path)
+	}
+
+	return reports, nil
+}
+
+// HasGitHubToken checks if a token for github.com is available.
+func (c *Config) HasGitHubToken() bool {
+	_, ok := c.Hosts.Lookup("github.com")
+
+	return c.Tokens.GitHub != "" || ok
+}
+
 // AllTokensSet checks if all of the tokens are set.
 func (c *Config) AllTokensSet() bool {
 	return c.IsSet("github-token") && c.IsSet("gitlab-token") && c.IsSet("url-token")
@@ -160,6 +333,14 @@ func (c *Config) ToTool(forced bool) *tool.Tool {
 		tool.Source.URL.Token = c.Tokens.URL
 	}
 
+	if isSet(c)("host-tokens") {
+		tool.Source.Tokens = c.Hosts
+	}
+
+	if isSet(c)("token-chain") {
+		tool.Source.Chain = c.TokenChain
+	}
+
 	if isSet(c)("no-cache") {
 		tool.NoCache = c.Cache.Disabled
 	}
@@ -172,6 +353,18 @@ func (c *Config) ToTool(forced bool) *tool.Tool {
 		tool.NoVerifyChecksum = c.NoVerifyChecksum
 	}
 
+	if isSet(c)("timeout") {
+		tool.Timeout = c.Timeout
+	}
+
+	if isSet(c)("http-retries") {
+		tool.Retries = &c.Network.Retries
+	}
+
+	if isSet(c)("http-retry-wait") {
+		tool.RetryWait = c.Network.RetryWait
+	}
+
 	if isSet(&c.Common)("output") {
 		tool.Output = c.Common.Output
 	}
@@ -2,6 +2,7 @@ package root
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -28,14 +29,32 @@ func Flags(cmd *cobra.Command) {
 	cmd.Flags().String("url-token", "", "url api token, defaulting to keyring, GODYL_URL_TOKEN, or URL_TOKEN")
 
 	cmd.Flags().Bool("keyring", false, "enable token retrieval from keyring")
+	cmd.Flags().String("token-store", "keyring", "token store to use with --keyring (keyring, file)")
+	cmd.Flags().StringSlice("token-chain", nil, "credential helpers to query for unset tokens (git, netrc)")
+	cmd.Flags().String("token-file", data.TokenFile().Path(), "path to the encrypted token file for the file token store")
 	cmd.Flags().IntP("parallel", "j", 0, "parallelism, 0 means unlimited.")
+	cmd.Flags().String("rate-limit-policy", "wait", "how to handle exhausted API rate limits (wait, fail)")
 	cmd.Flags().StringP("log-level", "l", logger.INFO.String(), fmt.Sprintf("log level (%v)", logger.LevelValues()))
 
 	cmd.Flags().BoolP("no-cache", "", false, "disable cache")
+	cmd.Flags().Duration("metadata-ttl", 5*time.Minute, "duration to use cached release metadata without revalidation")
+	cmd.Flags().Bool("archive-cache", false, "cache downloads verified against a checksum")
+	cmd.Flags().String("archive-cache-size", "1GB", "maximum size of the archive cache")
 	cmd.Flags().BoolP("no-verify-ssl", "k", false, "skip SSL verification")
+	cmd.Flags().String("ca-file", "", "path to a PEM bundle of additional certificate authorities to trust")
+	cmd.Flags().String("ca-dir", "", "path to a directory of PEM files of additional certificate authorities to trust")
+	cmd.Flags().String("http-proxy", "", "proxy for all requests, defaulting to HTTP_PROXY and HTTPS_PROXY")
+	cmd.Flags().String("no-proxy", "", "comma-separated hosts to exclude from proxying, defaulting to NO_PROXY")
+	cmd.Flags().Int("max-conns-per-host", 0, "maximum connections per host, 0 means unlimited")
+	cmd.Flags().Duration("http-timeout", 30*time.Second, "timeout to connect and receive the response headers")
+	cmd.Flags().Int("http-retries", 3, "retries of requests failing with connection or server errors")
+	cmd.Flags().Duration("http-retry-wait", time.Second, "initial wait between retries, doubled for each retry")
+	cmd.Flags().Duration("timeout", 0, "timeout of the resolution, download and commands of each tool, 0 means none")
 	cmd.Flags().Bool("no-progress", false, "disable progress bar")
+	cmd.Flags().String("output-format", OutputText, "output format of processing tools (text, ndjson)")
 	cmd.Flags().BoolP("no-verify-checksum", "C", false, "skip checksum verification")
 
 	cmd.Flags().StringP("error-file", "", "", "path to error log file, empty means stdout.")
+	cmd.Flags().StringSlice("report", nil, "reports of the results to write, as format=path (junit, markdown)")
 	cmd.Flags().CountP("verbose", "v", "increase verbosity (can be used multiple times)")
 }
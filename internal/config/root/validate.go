@@ -12,5 +12,17 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("%w: %q does not exist", ierrors.ErrUsage, c.Defaults)
 	}
 
+	if _, err := c.Cache.ArchivesLimit(); err != nil {
+		return fmt.Errorf("%w: %w", ierrors.ErrUsage, err)
+	}
+
+	if err := c.Mirrors.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ierrors.ErrUsage, err)
+	}
+
+	if _, err := c.Reports(); err != nil {
+		return fmt.Errorf("%w: %w", ierrors.ErrUsage, err)
+	}
+
 	return nil
 }
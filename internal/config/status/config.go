@@ -14,6 +14,9 @@ type Status struct {
 
 	// Tags are the tags to consider when checking the status.
 	Tags []string `mapstructure:"tags" yaml:"tags"`
+
+	// FailOn are the states of the tools to fail on.
+	FailOn []string `mapstructure:"fail-on" validate:"dive,oneof=missing modified unmanaged unknown outdated up_to_date" yaml:"fail-on"` //nolint:lll
 }
 
 // ToCommon converts the Status configuration to a shared.Common instance.
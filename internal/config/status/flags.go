@@ -8,4 +8,6 @@ func Flags(cmd *cobra.Command) {
 
 	cmd.Flags().StringP("output", "o", "./bin", "Output path for the downloaded tools")
 	cmd.Flags().StringSliceP("tags", "t", []string{"!native"}, "Tags to filter tools by. Prefix with '!' to exclude")
+	cmd.Flags().
+		StringSlice("fail-on", nil, "Fail if any tool is in one of the states (missing, modified, unmanaged, unknown, outdated, up_to_date)")
 }
@@ -3,7 +3,9 @@ package update
 
 import (
 	"github.com/idelchi/godyl/internal/config/shared"
+	"github.com/idelchi/godyl/internal/tools/sources"
 	"github.com/idelchi/godyl/internal/tools/strategy"
+	"github.com/idelchi/godyl/pkg/path/file"
 )
 
 // Update represents the configuration for the `update` command.
@@ -12,8 +14,8 @@ type Update struct {
 	// whether configuration values have been explicitly set or defaulted
 	shared.Tracker `mapstructure:"-" yaml:"-"`
 
-	// Version is the version to update to
-	Version string `mapstructure:"version" yaml:"version"`
+	// Version is the version to update or downgrade to
+	Version string `mapstructure:"version" validate:"required_if=Source url" yaml:"version"`
 
 	// Pre indicates whether to allow pre-release versions
 	Pre bool `mapstructure:"pre" yaml:"pre"`
@@ -21,11 +23,32 @@ type Update struct {
 	// Check indicates whether to only check for updates without applying them
 	Check bool `mapstructure:"check" yaml:"check"`
 
-	// Cleanup indicates whether to clean up old versions after updating
+	// Cleanup indicates whether to remove the hidden binary left behind by earlier updates on Windows
 	Cleanup bool `mapstructure:"cleanup" yaml:"cleanup"`
 
 	// Force indicates whether to force the update, ignoring any checks
 	Force bool `mapstructure:"force" yaml:"force"`
+
+	// Rollback restores the binary kept from before the last update
+	Rollback bool `mapstructure:"rollback" validate:"excluded_with=Version Check Force" yaml:"rollback"`
+
+	// Source is the source to update from
+	Source sources.Type `mapstructure:"source" validate:"oneof=github gitlab url" yaml:"source"`
+
+	// Repo is the repository to update from, as `owner/repo` for GitHub or `namespace/project` for GitLab
+	Repo string `mapstructure:"repo" yaml:"repo"`
+
+	// Server is the server of a GitHub Enterprise or self-hosted GitLab instance to update from
+	Server string `mapstructure:"server" yaml:"server"`
+
+	// URL is the URL of the release asset to update from, for the `url` source
+	URL string `mapstructure:"url" validate:"required_if=Source url" yaml:"url"`
+
+	// Checksum is the URL of the checksum file published with the release asset, for the `url` source
+	Checksum string `mapstructure:"checksum" yaml:"checksum"`
+
+	// PublicKey is the public key to verify the signature of the checksum file with
+	PublicKey file.File `mapstructure:"public-key" yaml:"public-key"`
 }
 
 // ToCommon converts the Update configuration to a shared.Common instance.
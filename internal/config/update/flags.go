@@ -6,9 +6,16 @@ import "github.com/spf13/cobra"
 func Flags(cmd *cobra.Command) {
 	cmd.Flags().SortFlags = false
 
-	cmd.Flags().String("version", "", "Version of the tool to install. Empty means latest.")
+	cmd.Flags().String("version", "", "Version of the tool to install, lower versions downgrade. Empty means latest.")
 	cmd.Flags().Bool("pre", false, "Enable pre-release versions")
 	cmd.Flags().Bool("check", false, "Check for updates only")
-	cmd.Flags().Bool("cleanup", false, "Cleanup after update (only valid for Windows)")
+	cmd.Flags().Bool("cleanup", false, "Remove the hidden binary left behind by earlier updates, keeping the one for --rollback (only valid for Windows)")
 	cmd.Flags().Bool("force", false, "Force update even if the current version is the latest")
+	cmd.Flags().Bool("rollback", false, "Restore the binary kept from before the last update")
+	cmd.Flags().String("source", "github", "Source to update from (github, gitlab, url)")
+	cmd.Flags().String("repo", "", "Repository to update from. Empty means the one godyl was built from.")
+	cmd.Flags().String("server", "", "Server of a GitHub Enterprise or self-hosted GitLab instance")
+	cmd.Flags().String("url", "", "URL of the release asset, for the url source")
+	cmd.Flags().String("checksum", "", "URL of the checksum file of the release asset, for the url source")
+	cmd.Flags().String("public-key", "", "PEM public key to verify the signature of the checksum file with")
 }
@@ -0,0 +1,20 @@
+// Package validate provides configuration and flags for the `godyl validate` command.
+package validate
+
+import "github.com/idelchi/godyl/internal/config/shared"
+
+// Validate represents the configuration for the `validate` command.
+type Validate struct {
+	// Tracker embed the common tracker configuration, allowing to tracker
+	// whether configuration values have been explicitly set or defaulted
+	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// Format is the output format of the findings
+	Format string `mapstructure:"format" validate:"oneof=text json" yaml:"format"`
+
+	// FailOn is the lowest severity of findings that fails the validation
+	FailOn string `mapstructure:"fail-on" validate:"oneof=error warning info" yaml:"fail-on"`
+
+	// Fix indicates whether trivial findings should be fixed in the tools file(s)
+	Fix bool `mapstructure:"fix" yaml:"fix"`
+}
@@ -0,0 +1,12 @@
+package validate
+
+import "github.com/spf13/cobra"
+
+// Flags adds the flags for the `godyl validate` command to the provided Cobra command.
+func Flags(cmd *cobra.Command) {
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().String("format", "text", "Output format of the findings (text, json)")
+	cmd.Flags().String("fail-on", "error", "Lowest severity of findings that fails the validation (error, warning, info)")
+	cmd.Flags().Bool("fix", false, "Fix trivial findings in place (not supported for stdin)")
+}
@@ -0,0 +1,31 @@
+// Package verify provides configuration and flags for the `godyl verify` command.
+package verify
+
+import "github.com/idelchi/godyl/internal/config/shared"
+
+// Verify represents the configuration for the `verify` command.
+type Verify struct {
+	// Tracker embed the common tracker configuration, allowing to tracker
+	// whether configuration values have been explicitly set or defaulted
+	shared.Tracker `mapstructure:"-" yaml:"-"`
+
+	// Output specifies the output directory of the installed tools
+	Output string `mapstructure:"output" yaml:"output"`
+
+	// Tags are the tags to consider when verifying.
+	Tags []string `mapstructure:"tags" yaml:"tags"`
+
+	// Strict fails on tools that cannot be verified as well.
+	Strict bool `mapstructure:"strict" yaml:"strict"`
+
+	// Format is the output format of the outcomes.
+	Format string `mapstructure:"format" validate:"oneof=text json" yaml:"format"`
+}
+
+// ToCommon converts the Verify configuration to a shared.Common instance.
+func (v Verify) ToCommon() shared.Common {
+	return shared.Common{
+		Output:  v.Output,
+		Tracker: v.Tracker,
+	}
+}
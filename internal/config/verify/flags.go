@@ -0,0 +1,13 @@
+package verify
+
+import "github.com/spf13/cobra"
+
+// Flags adds the flags for the `godyl verify` command to the provided Cobra command.
+func Flags(cmd *cobra.Command) {
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringP("output", "o", "./bin", "Output path of the installed tools")
+	cmd.Flags().StringSliceP("tags", "t", []string{"!native"}, "Tags to filter tools by. Prefix with '!' to exclude")
+	cmd.Flags().Bool("strict", false, "Fail on tools that cannot be verified as well")
+	cmd.Flags().String("format", "text", "Output format of the outcomes (text, json)")
+}
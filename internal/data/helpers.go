@@ -117,6 +117,36 @@ func CacheFile(folder folder.Folder) file.File {
 	return folder.WithFile("godyl.json")
 }
 
+// HistoryFile returns the file for the history of the changes to the tools within the specified folder.
+func HistoryFile(folder folder.Folder) file.File {
+	return folder.WithFile("history.jsonl")
+}
+
+// MetadataDir returns the folder for the cached release metadata within the specified folder.
+func MetadataDir(folder folder.Folder) folder.Folder {
+	return folder.Join("metadata")
+}
+
+// ArchivesDir returns the folder for the cached downloads within the specified folder.
+func ArchivesDir(folder folder.Folder) folder.Folder {
+	return folder.Join("archives")
+}
+
+// PartialsDir returns the folder for the partial downloads within the specified folder.
+func PartialsDir(folder folder.Folder) folder.Folder {
+	return folder.Join("partials")
+}
+
+// LocksDir returns the folder for the locks of the output directories within the specified folder.
+func LocksDir(folder folder.Folder) folder.Folder {
+	return folder.Join("locks")
+}
+
+// TokenFile returns the encrypted token file in the config directory.
+func TokenFile() file.File {
+	return ConfigDir().WithFile("tokens.age")
+}
+
 // ConfigFile returns the first existing “godyl” configuration file it finds.
 //
 // Search order:
@@ -40,9 +40,19 @@ func (p *Platform) Detect() error {
 		return fmt.Errorf("parsing architecture: %w", err)
 	}
 
+	// Determine the x86-64 level, to prefer the builds optimized for it
+	architecture.SetLevel(platform.DetectLevel())
+
 	// Set the default library based on the OS and distribution
 	library = library.Default(operatingSystem, distro)
 
+	// Determine the glibc version, to reject the builds requiring a newer one
+	if operatingSystem.Type() == "linux" && library.String() == "gnu" {
+		if version, err := platform.DetectGlibc(); err == nil {
+			library.SetVersion(version)
+		}
+	}
+
 	if architecture.Is64Bit() && operatingSystem.Type() == "linux" {
 		is32Bit, err := platform.Is32Bit()
 		if err == nil && is32Bit {
@@ -90,9 +90,11 @@ func (p *Platform) ToMap() map[string]any {
 	platformMap["ARCH_ALIASES"] = p.Architecture.Aliases()
 	platformMap["ARCH_VERSION"] = p.Architecture.Version()
 	platformMap["ARCH_LONG"] = p.Architecture.String()
+	platformMap["ARCH_LEVEL"] = p.Architecture.Level()
 	platformMap["IS_ARM"] = p.Architecture.IsARM()
 	platformMap["IS_X86"] = p.Architecture.IsX86()
 	platformMap["LIBRARY"] = p.Library.String()
+	platformMap["GLIBC"] = p.Library.Version()
 	platformMap["EXTENSION"] = p.Extension.String()
 	platformMap["DISTRIBUTION"] = p.Distribution.String()
 
@@ -1,11 +1,8 @@
 package platform
 
 import (
-	"bytes"
-	"context"
 	"errors"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
@@ -37,6 +34,7 @@ type Architecture struct {
 	canonical       string
 	alias           string
 	version         int
+	level           int
 	is32BitUserLand bool
 }
 
@@ -121,6 +119,10 @@ func (a *Architecture) ParseFrom(name string, comparisons ...func(string, string
 
 	lower := strings.ToLower(name)
 
+	// Match the level suffix of x86-64 separately, as part of neither the type nor the aliases.
+	level := parseLevel(lower)
+	lower = withoutLevel(lower)
+
 	archInfo := ArchInfo{}
 
 	for _, info := range archInfo.Supported() {
@@ -135,6 +137,11 @@ func (a *Architecture) ParseFrom(name string, comparisons ...func(string, string
 					a.Name = name
 					a.canonical = info.Type
 					a.alias = alias
+					a.level = 0
+
+					if info.Type == archAMD64 {
+						a.level = level
+					}
 
 					if info.Parse != nil {
 						version, err := info.Parse(alias)
@@ -176,12 +183,18 @@ func (a *Architecture) Is(other Architecture) bool {
 }
 
 // IsCompatibleWith checks if this architecture can run binaries built for another.
-// Considers architecture type and version compatibility (e.g., armv7 can run armv5).
+// Considers architecture type and version compatibility (e.g., armv7 can run armv5),
+// as well as the x86-64 level (e.g., amd64v3 can run amd64v2, but not amd64v4).
 func (a *Architecture) IsCompatibleWith(other Architecture) bool {
 	if a.IsUnset() || other.IsUnset() {
 		return false
 	}
 
+	// Binaries built for a higher x86-64 level than supported would crash.
+	if a.Level() < other.Level() {
+		return false
+	}
+
 	if a.Is(other) {
 		return true
 	}
@@ -219,6 +232,7 @@ func (a *Architecture) To32BitUserLand() {
 	switch a.canonical {
 	case archAMD64:
 		a.canonical = arch386
+		a.level = 0
 	case archARM64:
 		a.canonical = armString
 		a.version = armhfValue
@@ -251,6 +265,28 @@ func (a *Architecture) Version() int {
 	return a.version
 }
 
+// Level returns the x86-64 microarchitecture level (1-4) for amd64, and 0 for other architectures.
+// An amd64 architecture without a level is at the baseline level 1.
+func (a *Architecture) Level() int {
+	if a.canonical != archAMD64 {
+		return 0
+	}
+
+	return max(a.level, 1)
+}
+
+// SetLevel sets the x86-64 microarchitecture level of an amd64 architecture, such as the detected one of the host,
+// naming it along with the architecture such that it is kept when parsed again.
+// It has no effect on other architectures or for unknown levels.
+func (a *Architecture) SetLevel(level int) {
+	if a.canonical != archAMD64 || level == 0 {
+		return
+	}
+
+	a.level = level
+	a.Name = fmt.Sprintf("%s_v%d", withoutLevel(strings.ToLower(a.Name)), level)
+}
+
 // Is64Bit checks if the architecture is 64-bit capable.
 // Returns true for amd64 and arm64 architectures.
 func (a *Architecture) Is64Bit() bool {
@@ -272,17 +308,11 @@ func (a *Architecture) IsARM() bool {
 // Is32Bit detects if the system is running in 32-bit mode.
 // Uses getconf to determine the system's bit width.
 func Is32Bit() (bool, error) {
-	cmd := exec.CommandContext(context.Background(), "getconf", "LONG_BIT")
-
-	var out bytes.Buffer
-
-	cmd.Stdout = &out
-	if err := cmd.Run(); err != nil {
-		return false, fmt.Errorf("running getconf command: %w", err)
+	result, err := run("getconf", "LONG_BIT")
+	if err != nil {
+		return false, err
 	}
 
-	result := strings.TrimSpace(out.String())
-
 	value, err := strconv.Atoi(result)
 	if err != nil {
 		return false, fmt.Errorf("parsing bit value: %w", err)
@@ -188,6 +188,30 @@ func TestArchIsCompatibleWith(t *testing.T) {
 			right: "arm64",
 			want:  false,
 		},
+		{
+			name:  "amd64v3 compatible with x86_64_v2 (higher level runs lower)",
+			left:  "amd64v3",
+			right: "x86_64_v2",
+			want:  true,
+		},
+		{
+			name:  "amd64v3 compatible with baseline amd64",
+			left:  "amd64v3",
+			right: "amd64",
+			want:  true,
+		},
+		{
+			name:  "baseline amd64 not compatible with amd64v3",
+			left:  "amd64",
+			right: "amd64v3",
+			want:  false,
+		},
+		{
+			name:  "amd64v3 not compatible with x86-64-v4",
+			left:  "amd64v3",
+			right: "x86-64-v4",
+			want:  false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,63 @@
+package platform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// commandTimeout bounds the commands run to detect the platform, such that a hanging command does not stall the detection.
+const commandTimeout = 5 * time.Second
+
+// glibcRelease matches the glibc version as reported by `getconf GNU_LIBC_VERSION` or `ldd --version`.
+var glibcRelease = regexp.MustCompile(`(\d+\.\d+)\s*$`)
+
+// DetectGlibc returns the glibc version of the host, such as `2.36`.
+// It queries `getconf GNU_LIBC_VERSION` and falls back to `ldd --version`.
+// Returns an error on hosts without glibc, such as musl based ones.
+func DetectGlibc() (string, error) {
+	if out, err := run("getconf", "GNU_LIBC_VERSION"); err == nil {
+		if version, found := strings.CutPrefix(out, "glibc "); found {
+			return version, nil
+		}
+	}
+
+	out, err := run("ldd", "--version")
+	if err != nil {
+		return "", err
+	}
+
+	first, _, _ := strings.Cut(out, "\n")
+
+	if !strings.Contains(strings.ToLower(first), "glibc") && !strings.Contains(strings.ToLower(first), "gnu libc") {
+		return "", fmt.Errorf("no glibc found in %q", first)
+	}
+
+	match := glibcRelease.FindStringSubmatch(first)
+	if match == nil {
+		return "", fmt.Errorf("parsing glibc version from %q", first)
+	}
+
+	return match[1], nil
+}
+
+// run runs the command, bounded by commandTimeout, and returns its trimmed standard output.
+func run(name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+
+	var out bytes.Buffer
+
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running %s command: %w", name, err)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
@@ -0,0 +1,134 @@
+package platform
+
+import (
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/cpu"
+
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+// x86-64 microarchitecture levels, as defined by the x86-64 psABI.
+const (
+	levelV1 = 1
+	levelV2 = 2
+	levelV3 = 3
+	levelV4 = 4
+)
+
+var (
+	// levelSuffix matches a level suffix of an x86-64 architecture, such as `amd64v3`, `x86_64_v3` or `x86-64-v3`.
+	// Versions of the tool following the architecture, such as `amd64_v1.2.3`, are not matched.
+	levelSuffix = regexp.MustCompile(`(amd64|x86[_-]64|x64)[_-]?v([1-4])([^0-9.]|\.[^0-9]|$)`)
+
+	// featureSuffix matches an instruction set extension used to name builds for a level, such as `amd64-avx2`.
+	featureSuffix = regexp.MustCompile(`[_-]?avx(2|512)`)
+)
+
+// parseLevel returns the x86-64 level named in the lowercase string, or 0 if none is named.
+// The AVX2 and AVX-512 extensions name the levels 3 and 4 respectively.
+func parseLevel(lower string) int {
+	if match := levelSuffix.FindStringSubmatch(lower); match != nil {
+		level, _ := strconv.Atoi(match[2])
+
+		return level
+	}
+
+	if match := featureSuffix.FindStringSubmatch(lower); match != nil {
+		if match[1] == "512" {
+			return levelV4
+		}
+
+		return levelV3
+	}
+
+	return 0
+}
+
+// withoutLevel returns the lowercase string with the x86-64 level removed,
+// spelling `x86-64` as the `x86_64` alias such that it is not taken for `x86`.
+func withoutLevel(lower string) string {
+	lower = levelSuffix.ReplaceAllString(lower, "${1}${3}")
+	lower = featureSuffix.ReplaceAllString(lower, "")
+
+	return strings.ReplaceAll(lower, "x86-64", "x86_64")
+}
+
+// DetectLevel returns the x86-64 microarchitecture level of the host, or 0 if it cannot be determined.
+// On Linux, the CPU flags are read from `/proc/cpuinfo`, elsewhere they are queried with CPUID.
+func DetectLevel() int {
+	if runtime.GOOS == "linux" {
+		if content, err := file.New("/proc/cpuinfo").Read(); err == nil {
+			if flags := cpuFlags(string(content)); len(flags) > 0 {
+				return LevelFromFlags(flags)
+			}
+		}
+	}
+
+	if runtime.GOARCH != archAMD64 {
+		return 0
+	}
+
+	return levelFromCPUID()
+}
+
+// cpuFlags returns the flags of the first processor listed in the contents of `/proc/cpuinfo`.
+func cpuFlags(cpuinfo string) []string {
+	for line := range strings.SplitSeq(cpuinfo, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.TrimSpace(key) == "flags" {
+			return strings.Fields(value)
+		}
+	}
+
+	return nil
+}
+
+// LevelFromFlags returns the x86-64 microarchitecture level supported by a CPU with the flags,
+// as listed in `/proc/cpuinfo`.
+func LevelFromFlags(flags []string) int {
+	levels := []struct {
+		flags []string
+		level int
+	}{
+		{level: levelV2, flags: []string{"cx16", "lahf_lm", "popcnt", "pni", "sse4_1", "sse4_2", "ssse3"}},
+		{level: levelV3, flags: []string{"avx", "avx2", "bmi1", "bmi2", "f16c", "fma", "abm", "movbe", "xsave"}},
+		{level: levelV4, flags: []string{"avx512f", "avx512bw", "avx512cd", "avx512dq", "avx512vl"}},
+	}
+
+	level := levelV1
+
+	// Each level requires the previous ones, so count up until a level is not supported.
+	for _, l := range levels {
+		for _, flag := range l.flags {
+			if !slices.Contains(flags, flag) {
+				return level
+			}
+		}
+
+		level = l.level
+	}
+
+	return level
+}
+
+// levelFromCPUID returns the x86-64 microarchitecture level of the CPU as reported by CPUID.
+// Not all features of the levels are reported, so the level is determined from the ones that are.
+func levelFromCPUID() int {
+	x := cpu.X86
+
+	switch {
+	case !(x.HasCX16 && x.HasPOPCNT && x.HasSSE3 && x.HasSSSE3 && x.HasSSE41 && x.HasSSE42):
+		return levelV1
+	case !(x.HasAVX && x.HasAVX2 && x.HasBMI1 && x.HasBMI2 && x.HasFMA && x.HasOSXSAVE):
+		return levelV2
+	case !(x.HasAVX512F && x.HasAVX512BW && x.HasAVX512CD && x.HasAVX512DQ && x.HasAVX512VL):
+		return levelV3
+	default:
+		return levelV4
+	}
+}
@@ -0,0 +1,121 @@
+package platform_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/detect/platform"
+)
+
+func TestArchLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		wantType string
+		want     int
+	}{
+		{name: "amd64 without level is baseline", input: "tool_linux_amd64.tar.gz", wantType: "amd64", want: 1},
+		{name: "amd64v3 suffix", input: "tool_linux_amd64v3.tar.gz", wantType: "amd64", want: 3},
+		{name: "x86_64_v2 suffix", input: "tool-x86_64_v2-unknown-linux-gnu.tar.gz", wantType: "amd64", want: 2},
+		{name: "x86-64-v4 suffix", input: "tool-linux-x86-64-v4.zip", wantType: "amd64", want: 4},
+		{name: "avx2 build", input: "tool-linux-amd64-avx2.tar.gz", wantType: "amd64", want: 3},
+		{name: "avx512 build", input: "tool-linux-x86_64-avx512.tar.gz", wantType: "amd64", want: 4},
+		{name: "tool version after architecture", input: "tool_amd64_v1.2.3.tar.gz", wantType: "amd64", want: 1},
+		{name: "level ignored for other architectures", input: "tool_linux_arm64.tar.gz", wantType: "arm64", want: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var a platform.Architecture
+
+			if err := a.ParseFrom(tc.input); err != nil {
+				t.Fatalf("ParseFrom(%q) unexpected error: %v", tc.input, err)
+			}
+
+			if a.Type() != tc.wantType || a.Level() != tc.want {
+				t.Errorf("ParseFrom(%q) = %s level %d, want %s level %d", tc.input, a.Type(), a.Level(), tc.wantType, tc.want)
+			}
+		})
+	}
+}
+
+func TestArchLevelOverride(t *testing.T) {
+	t.Parallel()
+
+	var a platform.Architecture
+
+	a.Name = "x86_64_v3"
+
+	if err := a.Parse(); err != nil {
+		t.Fatalf("Parse(%q) unexpected error: %v", a.Name, err)
+	}
+
+	if a.Type() != "amd64" || a.Level() != 3 {
+		t.Errorf("Parse(%q) = %s level %d, want amd64 level 3", a.Name, a.Type(), a.Level())
+	}
+
+	// The detected level of the host is kept when parsed again.
+	var host platform.Architecture
+
+	if err := host.ParseFrom("x86_64", strings.EqualFold); err != nil {
+		t.Fatal(err)
+	}
+
+	host.SetLevel(2)
+
+	if err := host.Parse(); err != nil {
+		t.Fatalf("Parse(%q) unexpected error: %v", host.Name, err)
+	}
+
+	if host.Level() != 2 {
+		t.Errorf("Parse(%q) = level %d, want 2", host.Name, host.Level())
+	}
+
+	// The detected level of the host is applied to amd64 only.
+	var arm platform.Architecture
+
+	if err := arm.ParseFrom("arm64", strings.EqualFold); err != nil {
+		t.Fatal(err)
+	}
+
+	arm.SetLevel(3)
+
+	if arm.Level() != 0 {
+		t.Errorf("SetLevel on arm64 = level %d, want 0", arm.Level())
+	}
+}
+
+func TestLevelFromFlags(t *testing.T) {
+	t.Parallel()
+
+	v2 := "cx16 lahf_lm popcnt pni sse4_1 sse4_2 ssse3"
+	v3 := v2 + " avx avx2 bmi1 bmi2 f16c fma abm movbe xsave"
+	v4 := v3 + " avx512f avx512bw avx512cd avx512dq avx512vl"
+
+	tests := []struct {
+		name  string
+		flags string
+		want  int
+	}{
+		{name: "baseline", flags: "fpu sse sse2", want: 1},
+		{name: "v2", flags: v2, want: 2},
+		{name: "v3", flags: v3, want: 3},
+		{name: "v4", flags: v4, want: 4},
+		{name: "v3 without movbe is v2", flags: strings.Replace(v3, "movbe", "", 1), want: 2},
+		{name: "avx512 without v3 is v2", flags: v2 + " avx512f avx512bw avx512cd avx512dq avx512vl", want: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := platform.LevelFromFlags(strings.Fields(tc.flags)); got != tc.want {
+				t.Errorf("LevelFromFlags() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -2,11 +2,13 @@ package platform
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/goccy/go-yaml/ast"
 
 	"github.com/idelchi/godyl/pkg/unmarshal"
+	"github.com/idelchi/godyl/pkg/version"
 )
 
 const (
@@ -25,8 +27,14 @@ type Library struct {
 
 	// Raw contains the original string that was parsed.
 	alias string
+
+	// version is the glibc version, such as 2.34, for gnu.
+	version string
 }
 
+// glibcVersion matches the glibc version named along with the library, such as `gnu-2.34` or `glibc2.17`.
+var glibcVersion = regexp.MustCompile(`(?:gnu|glibc)[._-]?(\d+\.\d+)`)
+
 // IsNil returns true if the Library pointer is nil.
 func (l *Library) IsNil() bool {
 	return l.Name == ""
@@ -98,6 +106,11 @@ func (l *Library) ParseFrom(name string, comparisons ...func(string, string) boo
 					l.Name = name
 					l.canonical = info.Type
 					l.alias = alias
+					l.version = ""
+
+					if match := glibcVersion.FindStringSubmatch(lower); match != nil && info.Type == libGNU {
+						l.version = match[1]
+					}
 
 					return nil
 				}
@@ -161,6 +174,12 @@ func (l *Library) IsCompatibleWith(other Library) bool {
 		return false
 	}
 
+	// Binaries built against a newer glibc than this one fail to load its versioned symbols
+	if l.canonical == libGNU && other.canonical == libGNU && l.version != "" && other.version != "" &&
+		version.LessThan(l.version, other.version) {
+		return false
+	}
+
 	// Check if they're exactly the same library
 	if l.Is(other) {
 		return true
@@ -174,6 +193,23 @@ func (l *Library) IsCompatibleWith(other Library) bool {
 	return false
 }
 
+// Version returns the glibc version for gnu, such as `2.34`, or an empty string if unknown.
+func (l *Library) Version() string {
+	return l.version
+}
+
+// SetVersion sets the glibc version of a gnu library, such as the detected one of the host,
+// naming it along with the library such that it is kept when parsed again.
+// It has no effect on other libraries or for unknown versions.
+func (l *Library) SetVersion(version string) {
+	if l.canonical != libGNU || version == "" {
+		return
+	}
+
+	l.version = version
+	l.Name = l.alias + "-" + version
+}
+
 // String returns the canonical name of the library.
 func (l *Library) String() string {
 	return l.canonical
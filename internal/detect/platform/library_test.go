@@ -469,3 +469,102 @@ func TestLibraryDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestLibraryVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		host        string
+		asset       string
+		wantVersion string
+		want        bool
+	}{
+		{
+			name:        "older glibc is compatible",
+			host:        "2.36",
+			asset:       "tool-x86_64-linux-gnu-2.28.tar.gz",
+			wantVersion: "2.28",
+			want:        true,
+		},
+		{
+			name:        "same glibc is compatible",
+			host:        "2.36",
+			asset:       "tool_glibc2.36_amd64.tar.gz",
+			wantVersion: "2.36",
+			want:        true,
+		},
+		{
+			name:        "newer glibc is not compatible",
+			host:        "2.28",
+			asset:       "tool-linux-gnu.2.34-amd64.tar.gz",
+			wantVersion: "2.34",
+			want:        false,
+		},
+		{
+			name:        "unversioned glibc is compatible",
+			host:        "2.17",
+			asset:       "tool-x86_64-unknown-linux-gnu.tar.gz",
+			wantVersion: "",
+			want:        true,
+		},
+		{
+			name:        "unknown host glibc is compatible",
+			host:        "",
+			asset:       "tool-linux-gnu-2.39.tar.gz",
+			wantVersion: "2.39",
+			want:        true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			host := platform.Library{Name: "gnu"}
+			if err := host.Parse(); err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+
+			host.SetVersion(tc.host)
+
+			// The version must be kept when the host is parsed again.
+			if err := host.Parse(); err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+
+			if host.Version() != tc.host {
+				t.Errorf("host Version() = %q, want %q", host.Version(), tc.host)
+			}
+
+			var asset platform.Library
+
+			if err := asset.ParseFrom(tc.asset, strings.Contains); err != nil {
+				t.Fatalf("ParseFrom(%q) unexpected error: %v", tc.asset, err)
+			}
+
+			if asset.Version() != tc.wantVersion {
+				t.Errorf("ParseFrom(%q): Version() = %q, want %q", tc.asset, asset.Version(), tc.wantVersion)
+			}
+
+			if got := host.IsCompatibleWith(asset); got != tc.want {
+				t.Errorf("IsCompatibleWith: %q vs %q = %v, want %v", host.Name, tc.asset, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLibrarySetVersionOnlyGNU(t *testing.T) {
+	t.Parallel()
+
+	l := platform.Library{Name: "musl"}
+	if err := l.Parse(); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	l.SetVersion("2.36")
+
+	if l.Version() != "" || l.Name != "musl" {
+		t.Errorf("SetVersion on musl: Name = %q, Version() = %q, want %q and empty", l.Name, l.Version(), "musl")
+	}
+}
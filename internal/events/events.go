@@ -0,0 +1,168 @@
+// Package events streams the lifecycle events of the processed tools as newline-delimited JSON,
+// such that CI systems can follow the progress of a run without parsing the human-readable output.
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-getter/v2"
+)
+
+// Type is the type of an event.
+type Type string
+
+const (
+	// Queued is emitted for every tool before it is processed.
+	Queued Type = "queued"
+	// Skipped is emitted for a tool that was skipped, with the reason.
+	Skipped Type = "skipped"
+	// VersionResolved is emitted once the version of a tool is known.
+	VersionResolved Type = "version_resolved"
+	// AssetSelected is emitted once the URL to download a tool from is known.
+	AssetSelected Type = "asset_selected"
+	// Resolved is emitted for a tool that was resolved but not downloaded, such as in dry runs.
+	Resolved Type = "resolved"
+	// DownloadStarted is emitted when the transfer of a file starts, with its size if known.
+	DownloadStarted Type = "download_started"
+	// DownloadFinished is emitted when the transfer of a file ends, with the number of bytes transferred.
+	DownloadFinished Type = "download_finished"
+	// ChecksumVerified is emitted for a tool whose download was verified against its checksum.
+	ChecksumVerified Type = "checksum_verified"
+	// Installed is emitted for a tool that was installed.
+	Installed Type = "installed"
+	// Failed is emitted for a tool that failed, timed out or was interrupted, with the error.
+	Failed Type = "failed"
+	// Summary is emitted once all tools are processed.
+	Summary Type = "summary"
+)
+
+// Event is a lifecycle event of a tool, or the summary of the run.
+type Event struct {
+	Time       time.Time   `json:"time"`
+	Type       Type        `json:"event"`
+	Tool       string      `json:"tool,omitempty"`
+	Status     string      `json:"status,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	Version    string      `json:"version,omitempty"`
+	URL        string      `json:"url,omitempty"`
+	Checksum   string      `json:"checksum,omitempty"`
+	Output     string      `json:"output,omitempty"`
+	Bytes      int64       `json:"bytes,omitempty"`
+	TotalBytes int64       `json:"total_bytes,omitempty"`
+	Summary    *RunSummary `json:"summary,omitempty"`
+}
+
+// RunSummary is the outcome of a run.
+type RunSummary struct {
+	Errors      []ToolError `json:"errors"`
+	Total       int         `json:"total"`
+	Successful  int         `json:"successful"`
+	Failed      int         `json:"failed"`
+	TimedOut    int         `json:"timed_out"`
+	Skipped     int         `json:"skipped"`
+	Interrupted int         `json:"interrupted"`
+}
+
+// ToolError is the error of a tool that failed.
+type ToolError struct {
+	Tool    string `json:"tool"`
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Emitter emits events.
+type Emitter interface {
+	Emit(event Event)
+}
+
+// Writer emits events as one JSON object per line.
+type Writer struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewWriter returns a Writer emitting the events to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Emit writes the event as a single line, stamping it with the current time if unset.
+// Events of concurrently processed tools are never interleaved within a line.
+func (w *Writer) Emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, _ = w.w.Write(append(line, '\n'))
+}
+
+// Noop discards all events.
+type Noop struct{}
+
+// Emit does nothing.
+func (Noop) Emit(Event) {}
+
+// Track wraps the progress tracker to emit the start and end of the transfers of the tool downloaded from the URL.
+// The progress tracker only receives the file names, so the events report the URL instead.
+func Track(tracker getter.ProgressTracker, emitter Emitter, tool, url string) getter.ProgressTracker {
+	return &tracked{ProgressTracker: tracker, emitter: emitter, tool: tool, url: url}
+}
+
+// tracked emits the start and end of the transfers passing through the progress tracker.
+type tracked struct {
+	getter.ProgressTracker
+
+	emitter Emitter
+	tool    string
+	url     string
+}
+
+// TrackProgress emits the start of the transfer and wraps the stream to emit its end once closed.
+func (t *tracked) TrackProgress(src string, currentSize, totalSize int64, stream io.ReadCloser) io.ReadCloser {
+	t.emitter.Emit(Event{Type: DownloadStarted, Tool: t.tool, URL: t.url, TotalBytes: totalSize})
+
+	return &counted{
+		ReadCloser: t.ProgressTracker.TrackProgress(src, currentSize, totalSize, stream),
+		done: func(n int64) {
+			t.emitter.Emit(Event{Type: DownloadFinished, Tool: t.tool, URL: t.url, Bytes: n, TotalBytes: totalSize})
+		},
+	}
+}
+
+// counted counts the bytes read from the stream, reporting them once closed.
+type counted struct {
+	io.ReadCloser
+
+	done func(n int64)
+	once sync.Once
+	n    int64
+}
+
+// Read reads from the stream, counting the bytes read.
+func (c *counted) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
+
+// Close closes the stream and reports the bytes read.
+func (c *counted) Close() error {
+	err := c.ReadCloser.Close()
+
+	c.once.Do(func() { c.done(c.n) })
+
+	return err
+}
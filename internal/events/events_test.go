@@ -0,0 +1,103 @@
+package events_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/events"
+	"github.com/idelchi/godyl/pkg/download/progress"
+)
+
+// decode returns the events written as NDJSON.
+func decode(t *testing.T, buf *bytes.Buffer) []events.Event {
+	t.Helper()
+
+	var got []events.Event
+
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var event events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("decoding line %q: %v", scanner.Text(), err)
+		}
+
+		got = append(got, event)
+	}
+
+	return got
+}
+
+func TestWriterEmit(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	w := events.NewWriter(&buf)
+
+	const tools = 20
+
+	var wg sync.WaitGroup
+
+	for range tools {
+		wg.Go(func() {
+			w.Emit(events.Event{Type: events.Installed, Tool: "tool", Output: strings.Repeat("x", 1000)})
+		})
+	}
+
+	wg.Wait()
+
+	got := decode(t, &buf)
+	if len(got) != tools {
+		t.Fatalf("Emit() wrote %d events, want %d", len(got), tools)
+	}
+
+	for _, event := range got {
+		if event.Type != events.Installed || event.Time.IsZero() {
+			t.Errorf("Emit() wrote %+v, want a timestamped %q event", event, events.Installed)
+		}
+	}
+}
+
+func TestTrack(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	tracker := events.Track(progress.NewNoop(), events.NewWriter(&buf), "tool", "https://example.com/tool.tar.gz")
+
+	stream := tracker.TrackProgress("tool.tar.gz", 0, 5, io.NopCloser(strings.NewReader("hello")))
+
+	if _, err := io.Copy(io.Discard, stream); err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+
+	if err := stream.Close(); err != nil {
+		t.Fatalf("closing stream: %v", err)
+	}
+
+	// Closing twice must not report the transfer twice
+	_ = stream.Close()
+
+	got := decode(t, &buf)
+
+	want := []events.Event{
+		{Type: events.DownloadStarted, Tool: "tool", URL: "https://example.com/tool.tar.gz", TotalBytes: 5},
+		{Type: events.DownloadFinished, Tool: "tool", URL: "https://example.com/tool.tar.gz", Bytes: 5, TotalBytes: 5},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Track() emitted %d events, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		got[i].Time = want[i].Time
+		if got[i] != want[i] {
+			t.Errorf("event #%d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -1,18 +1,28 @@
 package github
 
 import (
+	"net/http"
 	"net/url"
 
 	"github.com/google/go-github/v74/github"
+
+	"github.com/idelchi/godyl/internal/metadata"
+	"github.com/idelchi/godyl/internal/network"
+	"github.com/idelchi/godyl/pkg/ratelimit"
 )
 
+// Scheduler schedules the API requests of all clients according to the rate limits reported by GitHub.
+//
+//nolint:gochecknoglobals	// The rate limits are shared by all clients of the process.
+var Scheduler = ratelimit.New(ratelimit.Wait)
+
 // NewClient creates a new GitHub client.
 // If a token is provided, the client is authenticated using the token.
 // Otherwise, an unauthenticated client is returned.
 // An optional baseURL may be provided to redirect API requests to a custom endpoint
 // (useful for testing with httptest servers).
 func NewClient(token string, baseURL ...string) *github.Client {
-	c := github.NewClient(nil)
+	c := github.NewClient(&http.Client{Transport: metadata.Transport(Scheduler.Transport(network.Retrying()))})
 
 	if token != "" {
 		c = c.WithAuthToken(token)
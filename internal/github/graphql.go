@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+
+	"github.com/idelchi/godyl/internal/release"
+)
+
+// BatchSize is the number of repositories looked up per GraphQL request.
+const BatchSize = 50
+
+// maxAssets is the number of assets retrieved per release.
+// Releases with more assets are left to the REST API, which pages through all of them.
+const maxAssets = 100
+
+// Name identifies a repository by its owner and name.
+type Name struct {
+	Owner string
+	Repo  string
+}
+
+// String returns the name in the `owner/repo` format.
+func (n Name) String() string {
+	return n.Owner + "/" + n.Repo
+}
+
+// graphQLRelease is a release as returned by the GraphQL API.
+type graphQLRelease struct {
+	Name          string `json:"name"`
+	TagName       string `json:"tagName"`
+	Description   string `json:"description"`
+	ReleaseAssets struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			Name        string `json:"name"`
+			DownloadURL string `json:"downloadUrl"`
+			ContentType string `json:"contentType"`
+			Digest      string `json:"digest"`
+		} `json:"nodes"`
+	} `json:"releaseAssets"`
+}
+
+// LatestReleases retrieves the latest releases of the repositories with the GraphQL API,
+// looking up BatchSize repositories per request.
+// Repositories without a release, which are not found or whose release has too many assets are left out,
+// such that they are looked up with the REST API instead.
+// The client must be authenticated, as the GraphQL API requires a token.
+func LatestReleases(ctx context.Context, client *github.Client, names []Name) (map[Name]*release.Release, error) {
+	releases := make(map[Name]*release.Release, len(names))
+
+	for start := 0; start < len(names); start += BatchSize {
+		batch := names[start:min(start+BatchSize, len(names))]
+
+		if err := latestReleases(ctx, client, batch, releases); err != nil {
+			return releases, err
+		}
+	}
+
+	return releases, nil
+}
+
+// latestReleases retrieves the latest releases of the repositories with a single GraphQL request.
+func latestReleases(ctx context.Context, client *github.Client, names []Name, releases map[Name]*release.Release) error {
+	var query strings.Builder
+
+	query.WriteString("query {")
+
+	for i, name := range names {
+		fmt.Fprintf(&query, `
+  r%d: repository(owner: %s, name: %s) {
+    latestRelease {
+      name tagName description
+      releaseAssets(first: %d) { totalCount nodes { name downloadUrl contentType digest } }
+    }
+  }`, i, strconv.Quote(name.Owner), strconv.Quote(name.Repo), maxAssets)
+	}
+
+	query.WriteString("\n}")
+
+	req, err := client.NewRequest(http.MethodPost, "graphql", map[string]string{"query": query.String()})
+	if err != nil {
+		return fmt.Errorf("creating GraphQL request: %w", err)
+	}
+
+	// Repositories that are not found are reported as errors next to the data of the others.
+	var response struct {
+		Data map[string]*struct {
+			LatestRelease *graphQLRelease `json:"latestRelease"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if _, err := client.Do(ctx, req, &response); err != nil {
+		return fmt.Errorf("querying latest releases: %w", err)
+	}
+
+	if response.Data == nil && len(response.Errors) > 0 {
+		return fmt.Errorf("querying latest releases: %s", response.Errors[0].Message)
+	}
+
+	for i, name := range names {
+		repository := response.Data["r"+strconv.Itoa(i)]
+		if repository == nil || repository.LatestRelease == nil {
+			continue
+		}
+
+		latest := repository.LatestRelease
+		if latest.ReleaseAssets.TotalCount > len(latest.ReleaseAssets.Nodes) {
+			continue
+		}
+
+		assets := make(release.Assets, 0, len(latest.ReleaseAssets.Nodes))
+
+		for _, asset := range latest.ReleaseAssets.Nodes {
+			assets = append(assets, release.Asset{
+				Name:   asset.Name,
+				URL:    asset.DownloadURL,
+				Type:   asset.ContentType,
+				Digest: asset.Digest,
+			})
+		}
+
+		releases[name] = &release.Release{
+			Name:   latest.Name,
+			Tag:    latest.TagName,
+			Body:   latest.Description,
+			Assets: assets,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package github_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	internalgithub "github.com/idelchi/godyl/internal/github"
+	"github.com/idelchi/godyl/internal/release"
+)
+
+// repositoryAlias matches the aliased repository lookups of a GraphQL query.
+var repositoryAlias = regexp.MustCompile(`(r\d+): repository\(owner: "([^"]*)", name: "([^"]*)"\)`)
+
+// newGraphQLServer serves GraphQL queries, answering each repository lookup with the respond function.
+func newGraphQLServer(t *testing.T, requests *atomic.Int32, respond func(owner, repo string) any) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
+		var body struct {
+			Query string `json:"query"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data := map[string]any{}
+
+		var errs []map[string]string
+
+		for _, match := range repositoryAlias.FindAllStringSubmatch(body.Query, -1) {
+			response := respond(match[2], match[3])
+			if response == nil {
+				errs = append(errs, map[string]string{
+					"message": fmt.Sprintf("Could not resolve to a Repository with the name '%s/%s'.", match[2], match[3]),
+				})
+			}
+
+			data[match[1]] = response
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(map[string]any{"data": data, "errors": errs}); err != nil {
+			t.Errorf("encoding response: %v", err)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// latestRelease returns the GraphQL response of a repository with a latest release with the assets.
+func latestRelease(tag string, total int, assets ...string) map[string]any {
+	nodes := make([]map[string]string, 0, len(assets))
+
+	for _, asset := range assets {
+		nodes = append(nodes, map[string]string{
+			"name":        asset,
+			"downloadUrl": "https://github.com/o/r/releases/download/" + tag + "/" + asset,
+			"contentType": "application/gzip",
+			"digest":      "sha256:" + asset,
+		})
+	}
+
+	return map[string]any{
+		"latestRelease": map[string]any{
+			"name":          "Release " + tag,
+			"tagName":       tag,
+			"description":   "notes",
+			"releaseAssets": map[string]any{"totalCount": total, "nodes": nodes},
+		},
+	}
+}
+
+func TestLatestReleases(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := newGraphQLServer(t, &requests, func(owner, repo string) any {
+		switch repo {
+		case "tool":
+			return latestRelease("v1.2.3", 1, "tool.tar.gz")
+		case "unreleased":
+			return map[string]any{"latestRelease": nil}
+		case "crowded":
+			return latestRelease("v1.0.0", 150, "a.tar.gz")
+		case "missing":
+			return nil
+		default:
+			return latestRelease("v0.1.0", 0)
+		}
+	})
+
+	client := internalgithub.NewClient("token", server.URL+"/")
+
+	names := []internalgithub.Name{
+		{Owner: "owner", Repo: "tool"},
+		{Owner: "owner", Repo: "unreleased"},
+		{Owner: "owner", Repo: "crowded"},
+		{Owner: "owner", Repo: "missing"},
+	}
+
+	releases, err := internalgithub.LatestReleases(t.Context(), client, names)
+	if err != nil {
+		t.Fatalf("LatestReleases() error = %v", err)
+	}
+
+	want := map[internalgithub.Name]*release.Release{
+		{Owner: "owner", Repo: "tool"}: {
+			Name: "Release v1.2.3",
+			Tag:  "v1.2.3",
+			Body: "notes",
+			Assets: release.Assets{{
+				Name:   "tool.tar.gz",
+				URL:    "https://github.com/o/r/releases/download/v1.2.3/tool.tar.gz",
+				Type:   "application/gzip",
+				Digest: "sha256:tool.tar.gz",
+			}},
+		},
+	}
+
+	if diff := cmp.Diff(want, releases); diff != "" {
+		t.Errorf("LatestReleases() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("LatestReleases() sent %d requests, want 1", got)
+	}
+}
+
+func TestLatestReleasesBatches(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := newGraphQLServer(t, &requests, func(_, _ string) any {
+		return latestRelease("v1.0.0", 0)
+	})
+
+	client := internalgithub.NewClient("token", server.URL+"/")
+
+	names := make([]internalgithub.Name, 0, internalgithub.BatchSize+1)
+	for i := range internalgithub.BatchSize + 1 {
+		names = append(names, internalgithub.Name{Owner: "owner", Repo: fmt.Sprintf("tool-%d", i)})
+	}
+
+	releases, err := internalgithub.LatestReleases(t.Context(), client, names)
+	if err != nil {
+		t.Fatalf("LatestReleases() error = %v", err)
+	}
+
+	if len(releases) != len(names) {
+		t.Errorf("LatestReleases() returned %d releases, want %d", len(releases), len(names))
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("LatestReleases() sent %d requests, want 2", got)
+	}
+}
+
+func TestLatestReleasesUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := newGraphQLServer(t, &requests, func(_, _ string) any { return nil })
+
+	client := internalgithub.NewClient("", server.URL+"/")
+
+	if _, err := internalgithub.LatestReleases(t.Context(), client, []internalgithub.Name{{Owner: "o", Repo: "r"}}); err == nil {
+		t.Error("LatestReleases() error = nil, want error")
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-github/v74/github"
 
+	"github.com/idelchi/godyl/internal/metadata"
+	"github.com/idelchi/godyl/internal/network"
 	"github.com/idelchi/godyl/internal/release"
 )
 
@@ -16,7 +18,7 @@ import (
 // It contains a GitHub client for making API calls.
 type Repository struct {
 	client    *github.Client
-	transport http.RoundTripper // HTTP transport for web scraping; defaults to http.DefaultTransport.
+	transport http.RoundTripper // HTTP transport for web scraping; defaults to the metadata cache.
 	Owner     string
 	Repo      string
 }
@@ -28,7 +30,7 @@ func NewRepository(owner, repo string, client *github.Client) *Repository {
 		Owner:     owner,
 		Repo:      repo,
 		client:    client,
-		transport: http.DefaultTransport,
+		transport: metadata.Transport(network.Retrying()),
 	}
 }
 
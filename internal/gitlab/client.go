@@ -2,16 +2,26 @@ package gitlab
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/idelchi/godyl/internal/metadata"
+	"github.com/idelchi/godyl/internal/network"
 )
 
 // NewClient creates a new GitLab client.
 // If a token is provided, the client is authenticated using the token.
 // If baseURL is provided, the client will connect to that GitLab instance instead of gitlab.com.
-func NewClient(token, baseURL string) (*gitlab.Client, error) {
-	var options []gitlab.ClientOptionFunc
+// Failed requests are retried with the policy.
+func NewClient(token, baseURL string, policy network.Policy) (*gitlab.Client, error) {
+	options := []gitlab.ClientOptionFunc{
+		// The client retries failed requests itself.
+		gitlab.WithHTTPClient(&http.Client{Transport: metadata.Transport(network.Transport())}),
+		gitlab.WithCustomRetryMax(policy.Retries),
+		gitlab.WithCustomRetryWaitMinMax(policy.WaitMin, policy.WaitMax),
+	}
 
 	// If baseURL is provided, configure the client to use it
 	if baseURL != "" {
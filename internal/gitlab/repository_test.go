@@ -11,6 +11,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	internalgitlab "github.com/idelchi/godyl/internal/gitlab"
+	"github.com/idelchi/godyl/internal/network"
 	"github.com/idelchi/godyl/internal/release"
 )
 
@@ -39,7 +40,7 @@ func newGitLabTestServer(t *testing.T, mux *http.ServeMux) *internalgitlab.Repos
 	server := httptest.NewServer(mux)
 	t.Cleanup(server.Close)
 
-	client, err := internalgitlab.NewClient("", server.URL)
+	client, err := internalgitlab.NewClient("", server.URL, network.PolicyFrom(t.Context()))
 	if err != nil {
 		t.Fatalf("creating client: %v", err)
 	}
@@ -1,9 +1,11 @@
 package goi
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os/exec"
 	"sync"
 
@@ -12,6 +14,8 @@ import (
 
 	"github.com/idelchi/godyl/internal/data"
 	"github.com/idelchi/godyl/internal/debug"
+	"github.com/idelchi/godyl/internal/mirrors"
+	"github.com/idelchi/godyl/internal/network"
 	"github.com/idelchi/godyl/internal/tools/checksum"
 	"github.com/idelchi/godyl/pkg/download"
 	"github.com/idelchi/godyl/pkg/path/file"
@@ -25,6 +29,7 @@ type Binary struct {
 	Dir              folder.Folder
 	noVerifySSL      bool
 	noVerifyChecksum bool
+	partials         folder.Folder
 	progress         getter.ProgressTracker
 }
 
@@ -33,8 +38,11 @@ var mutex sync.Mutex //nolint:gochecknoglobals 		// TODO(Idelchi): Address this
 
 // New creates a new Binary instance, setting up the directory, downloading the latest release if necessary,
 // and initializing environment variables. It ensures thread-safe execution by using a mutex lock.
+// Interrupted downloads are kept in partials to resume them, unless unset.
 func New(
+	ctx context.Context,
 	noVerifySSL, downloadIfMissing, noVerifyChecksum bool,
+	partials folder.Folder,
 	progress getter.ProgressTracker,
 ) (binary Binary, err error) {
 	mutex.Lock()
@@ -43,6 +51,7 @@ func New(
 	binary.noVerifySSL = noVerifySSL
 	binary.progress = progress
 	binary.noVerifyChecksum = noVerifyChecksum
+	binary.partials = partials
 
 	// 1: Search for go binary on system
 	if path, err := exec.LookPath("go"); err == nil {
@@ -69,7 +78,7 @@ func New(
 		return binary, fmt.Errorf("creating dir: %w", err)
 	}
 
-	release, err := binary.Latest()
+	release, err := binary.Latest(ctx)
 	if err != nil {
 		return binary, err
 	}
@@ -98,7 +107,7 @@ func New(
 
 	debug.Debug("Downloading Go toolchain: %q", target.FileName)
 
-	err = binary.Download(target)
+	err = binary.Download(ctx, target)
 	if err != nil {
 		return binary, err
 	}
@@ -125,13 +134,17 @@ func (b *Binary) Find(paths ...string) (file.File, error) {
 
 // Download downloads the Go binary from the provided path and saves it to the directory.
 // It returns an error if the download or file validation fails.
-func (b *Binary) Download(target Target) error {
+func (b *Binary) Download(ctx context.Context, target Target) error {
 	url := "https://go.dev/dl/" + target.FileName
 
-	options := []download.Option{}
+	policy := network.PolicyFrom(ctx)
 
-	if b.noVerifySSL {
-		options = append(options, download.WithInsecureSkipVerify())
+	options := []download.Option{
+		download.WithResume(b.partials),
+		download.WithMirrors(mirrors.Rules()),
+		download.WithTransport(network.TransportFor(b.noVerifySSL)),
+		download.WithMaxRetries(policy.Retries),
+		download.WithRetryWaits(policy.WaitMin, policy.WaitMax),
 	}
 
 	if b.progress != nil {
@@ -152,7 +165,7 @@ func (b *Binary) Download(target Target) error {
 
 	downloader := download.New(options...)
 
-	destination, err := downloader.Download(url, b.Dir.Path())
+	destination, err := downloader.Download(ctx, url, b.Dir.Path())
 	if err != nil {
 		return fmt.Errorf("downloading %q: %w", url, err)
 	}
@@ -178,16 +191,31 @@ func (b *Binary) CleanUp() error {
 	return nil
 }
 
-// Latest fetches the latest Go release information from the official Go download page.
+// releasesURL lists the Go releases, the latest first.
+const releasesURL = "https://go.dev/dl/?mode=json"
+
+// Latest fetches the latest Go release information from the official Go download page, or its mirror.
 // It returns the most recent release or an error if the process fails.
-func (b *Binary) Latest() (Release, error) {
-	client := resty.New()
+func (b *Binary) Latest(ctx context.Context) (Release, error) {
+	client := resty.New().SetTransport(network.RetryingFor(b.noVerifySSL))
+
+	url, rule, mirrored := mirrors.Rules().Rewrite(releasesURL)
+
+	resp, err := client.R().SetContext(ctx).SetHeaderMultiValues(rule.Header()).Get(url)
+	if err == nil && mirrored && rule.Fallback && resp.StatusCode() == http.StatusNotFound {
+		debug.Debug("%q not found on the mirror, falling back to %q", url, releasesURL)
+
+		resp, err = client.R().SetContext(ctx).Get(releasesURL)
+	}
 
-	resp, err := client.R().Get("https://go.dev/dl/?mode=json")
 	if err != nil {
 		return Release{}, fmt.Errorf("fetching latest Go release: %w", err)
 	}
 
+	if resp.StatusCode() != http.StatusOK {
+		return Release{}, fmt.Errorf("fetching latest Go release: unexpected status code: %d", resp.StatusCode())
+	}
+
 	var releases []Release
 
 	if err := json.Unmarshal(resp.Body(), &releases); err != nil {
@@ -3,6 +3,7 @@ package goi
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -19,10 +20,10 @@ type Installer struct {
 
 // Install executes the `go install` command for the provided package path.
 // It captures both stdout and stderr, returning them as output, and reports errors if the installation fails.
-func (i *Installer) Install(path string) (output string, err error) {
+func (i *Installer) Install(ctx context.Context, path string) (output string, err error) {
 	var stdoutBuf, stderrBuf bytes.Buffer
 
-	ctx, cancel := context.WithTimeout(context.Background(), download.DefaultTimeout)
+	ctx, cancel := context.WithTimeout(ctx, download.DefaultTimeout)
 	defer cancel()
 
 	// Prepare the command
@@ -50,6 +51,11 @@ func (i *Installer) Install(path string) (output string, err error) {
 
 	// Run the command
 	if err := cmd.Run(); err != nil {
+		// Check if it was interrupted
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return stdoutBuf.String() + "\n" + stderrBuf.String(), fmt.Errorf("go install interrupted: %w", ctx.Err())
+		}
+
 		// Check if it was a timeout
 		if ctx.Err() == context.DeadlineExceeded {
 			return stdoutBuf.String() + "\n" + stderrBuf.String(), fmt.Errorf(
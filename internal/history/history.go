@@ -0,0 +1,224 @@
+// Package history records the changes made to the installed tools in an append-only log,
+// such that it can be traced who changed a tool on a system, when and from which version.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
+	"github.com/idelchi/godyl/pkg/wildcard"
+)
+
+// Action is the change made to a tool.
+type Action string
+
+const (
+	// Install is the installation of a tool that did not exist.
+	Install Action = "install"
+	// Update is the replacement of an existing tool.
+	Update Action = "update"
+	// Pruned is the removal of a tool from the cache by `cache clean`, after its executable was found missing.
+	// It records who noticed the removal, not who removed the executable.
+	Pruned Action = "pruned"
+)
+
+// Entry is a change made to a tool.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Tool     string    `json:"tool"`
+	Action   Action    `json:"action"`
+	From     string    `json:"from,omitempty"`
+	To       string    `json:"to,omitempty"`
+	Path     string    `json:"path,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Checksum string    `json:"checksum,omitempty"`
+	Source   string    `json:"source,omitempty"`
+	Godyl    string    `json:"godyl,omitempty"`
+	User     string    `json:"user,omitempty"`
+	Host     string    `json:"host,omitempty"`
+	Outcome  string    `json:"outcome"`
+	Error    string    `json:"error,omitempty"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// History is an append-only log of entries, stored as one JSON object per line.
+type History struct {
+	file.File
+
+	mu sync.Mutex
+}
+
+// New returns the history stored in the file.
+func New(file file.File) *History {
+	return &History{File: file}
+}
+
+// Append adds the entries to the end of the history, stamping them with the current time, user and host if unset.
+func (h *History) Append(entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var lines []byte
+
+	for _, entry := range entries {
+		if entry.Time.IsZero() {
+			entry.Time = time.Now().UTC()
+		}
+
+		if entry.User == "" {
+			entry.User = currentUser()
+		}
+
+		if entry.Host == "" {
+			entry.Host, _ = os.Hostname()
+		}
+
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("encoding history entry: %w", err)
+		}
+
+		lines = append(append(lines, line...), '\n')
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := folder.New(h.Dir()).Create(); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+
+	const perm = 0o600
+
+	// Entries are only ever appended, never rewritten.
+	f, err := os.OpenFile(h.Path(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, perm)
+	if err != nil {
+		return fmt.Errorf("opening history: %w", err)
+	}
+
+	if _, err := f.Write(lines); err != nil {
+		return errors.Join(fmt.Errorf("writing history: %w", err), f.Close())
+	}
+
+	return f.Close()
+}
+
+// Read returns all entries of the history, oldest first.
+// A missing history has no entries.
+func (h *History) Read() ([]Entry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f, err := h.Open()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err //nolint:wrapcheck 	// Error does not need additional wrapping.
+	}
+	defer f.Close()
+
+	var entries []Entry
+
+	scanner := bufio.NewScanner(f)
+
+	const maxLine = 1 << 20
+
+	scanner.Buffer(nil, maxLine)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("decoding history %q, line %d: %w", h.Path(), line, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Filter selects entries of the history.
+type Filter struct {
+	// Since excludes entries older than the time, if set.
+	Since time.Time
+	// Tools selects entries of tools matching any of the patterns, all if empty.
+	Tools []string
+	// Actions selects entries with any of the actions, all if empty.
+	Actions []Action
+	// Outcomes selects entries with any of the outcomes, all if empty.
+	Outcomes []string
+	// Limit keeps only the most recent entries, all if zero.
+	Limit int
+}
+
+// Apply returns the entries selected by the filter, in their original order.
+func (f Filter) Apply(entries []Entry) []Entry {
+	var selected []Entry
+
+	for _, entry := range entries {
+		if f.matches(entry) {
+			selected = append(selected, entry)
+		}
+	}
+
+	if f.Limit > 0 && len(selected) > f.Limit {
+		selected = selected[len(selected)-f.Limit:]
+	}
+
+	return selected
+}
+
+// matches reports whether the entry is selected by the filter.
+func (f Filter) matches(entry Entry) bool {
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+
+	if len(f.Actions) > 0 && !slices.Contains(f.Actions, entry.Action) {
+		return false
+	}
+
+	if len(f.Outcomes) > 0 && !slices.Contains(f.Outcomes, entry.Outcome) {
+		return false
+	}
+
+	return len(f.Tools) == 0 || slices.ContainsFunc(f.Tools, func(pattern string) bool {
+		return wildcard.Match(pattern, entry.Tool)
+	})
+}
+
+// currentUser returns the name of the user running the process.
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+
+	for _, key := range []string{"USER", "USERNAME"} {
+		if name := os.Getenv(key); name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,98 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/idelchi/godyl/internal/history"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+func TestHistoryAppendRead(t *testing.T) {
+	t.Parallel()
+
+	h := history.New(file.New(t.TempDir(), "nested", "history.jsonl"))
+
+	entries, err := h.Read()
+	if err != nil {
+		t.Fatalf("reading missing history: %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries for a missing history, got %d", len(entries))
+	}
+
+	if err := h.Append(history.Entry{Tool: "jq", Action: history.Install, To: "1.7", Outcome: "ok"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.Append(history.Entry{Tool: "jq", Action: history.Update, From: "1.7", To: "1.8", Outcome: "ok"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = h.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	if entries[0].Action != history.Install || entries[1].From != "1.7" || entries[1].To != "1.8" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+
+	if entries[0].Time.IsZero() {
+		t.Error("expected the time to be stamped")
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	entries := []history.Entry{
+		{Tool: "jq", Action: history.Install, Outcome: "ok", Time: now.Add(-48 * time.Hour)},
+		{Tool: "yq", Action: history.Install, Outcome: "failed", Time: now.Add(-time.Hour)},
+		{Tool: "jq", Action: history.Update, Outcome: "ok", Time: now.Add(-time.Minute)},
+		{Tool: "gh", Action: history.Pruned, Outcome: "ok", Time: now},
+	}
+
+	tests := []struct {
+		name   string
+		filter history.Filter
+		want   []string
+	}{
+		{name: "all", filter: history.Filter{}, want: []string{"jq", "yq", "jq", "gh"}},
+		{name: "tools", filter: history.Filter{Tools: []string{"*q"}}, want: []string{"jq", "yq", "jq"}},
+		{name: "wildcard", filter: history.Filter{Tools: []string{"g*"}}, want: []string{"gh"}},
+		{name: "actions", filter: history.Filter{Actions: []history.Action{history.Install}}, want: []string{"jq", "yq"}},
+		{name: "outcomes", filter: history.Filter{Outcomes: []string{"failed"}}, want: []string{"yq"}},
+		{name: "since", filter: history.Filter{Since: now.Add(-2 * time.Hour)}, want: []string{"yq", "jq", "gh"}},
+		{name: "limit keeps most recent", filter: history.Filter{Limit: 2}, want: []string{"jq", "gh"}},
+		{
+			name:   "combined",
+			filter: history.Filter{Tools: []string{"jq"}, Outcomes: []string{"ok"}, Limit: 1},
+			want:   []string{"jq"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, entry := range tt.filter.Apply(entries) {
+				got = append(got, entry.Tool)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Apply() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/idelchi/godyl/internal/tools/fallbacks"
+	"github.com/idelchi/godyl/internal/tools/sources"
+)
+
+// fixKind identifies an automatic fix.
+type fixKind int
+
+const (
+	// fixNone indicates that the finding cannot be fixed automatically.
+	fixNone fixKind = iota
+	// fixFallbacks replaces the fallbacks of a tool.
+	fixFallbacks
+	// fixDuplicate removes a tool.
+	fixDuplicate
+)
+
+// fix describes how to fix a finding.
+type fix struct {
+	// fallbacks to keep for fixFallbacks.
+	fallbacks fallbacks.Fallbacks
+	kind      fixKind
+}
+
+// ErrNotFixable is returned when the tools file does not have the expected layout.
+var ErrNotFixable = errors.New("cannot apply fixes")
+
+// Fix applies the fixes of all fixable findings to the tools file contents,
+// preserving comments. Returns the new contents and the findings with `Fixed` set accordingly.
+// The findings must originate from the same contents.
+func Fix(data []byte, findings Findings) ([]byte, Findings, error) {
+	file, err := parser.ParseBytes(data, parser.ParseComments)
+	if err != nil {
+		return data, findings, fmt.Errorf("%w: parsing tools file: %w", ErrNotFixable, err)
+	}
+
+	if len(file.Docs) != 1 {
+		return data, findings, fmt.Errorf("%w: expected a single YAML document", ErrNotFixable)
+	}
+
+	sequence, ok := file.Docs[0].Body.(*ast.SequenceNode)
+	if !ok {
+		return data, findings, fmt.Errorf("%w: expected a list of tools", ErrNotFixable)
+	}
+
+	fixed := slices.Clone(findings)
+
+	var remove []int
+
+	for i, finding := range fixed {
+		if !finding.Fixable || finding.Index < 0 || finding.Index >= len(sequence.Values) {
+			continue
+		}
+
+		switch finding.fix.kind {
+		case fixFallbacks:
+			mapping, ok := sequence.Values[finding.Index].(*ast.MappingNode)
+			if !ok {
+				continue
+			}
+
+			fixed[i].Fixed = setFallbacks(mapping, finding.fix.fallbacks)
+		case fixDuplicate:
+			remove = append(remove, finding.Index)
+
+			fixed[i].Fixed = true
+		case fixNone:
+		}
+	}
+
+	// Remove from the back to keep the indices valid.
+	slices.Sort(remove)
+
+	for _, index := range slices.Backward(slices.Compact(remove)) {
+		sequence.Values = slices.Delete(sequence.Values, index, index+1)
+
+		if index < len(sequence.ValueHeadComments) {
+			sequence.ValueHeadComments = slices.Delete(sequence.ValueHeadComments, index, index+1)
+		}
+	}
+
+	return []byte(strings.TrimRight(file.String(), "\n") + "\n"), fixed, nil
+}
+
+// setFallbacks replaces the `fallbacks` entry of the mapping with the given values,
+// removing the key altogether if there are none left.
+func setFallbacks(mapping *ast.MappingNode, keep fallbacks.Fallbacks) bool {
+	for i, value := range mapping.Values {
+		if value.Key.String() != "fallbacks" {
+			continue
+		}
+
+		if len(keep) == 0 {
+			mapping.Values = slices.Delete(mapping.Values, i, i+1)
+
+			return true
+		}
+
+		sequence, ok := value.Value.(*ast.SequenceNode)
+		if !ok {
+			return false
+		}
+
+		var values []ast.Node
+
+		seen := map[string]bool{}
+
+		for _, node := range sequence.Values {
+			name := node.String()
+			if scalar, ok := node.(*ast.StringNode); ok {
+				name = scalar.Value
+			}
+
+			if seen[name] || !slices.Contains(keep, sources.Type(name)) {
+				continue
+			}
+
+			seen[name] = true
+
+			values = append(values, node)
+		}
+
+		sequence.Values = values
+		sequence.ValueHeadComments = nil
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,131 @@
+// Package lint reports problems in tools configurations that pass structural validation,
+// but are unlikely to behave as intended.
+//
+//go:generate go tool enumer -type=Severity -output severity_enumer___generated.go -transform=lower -json
+package lint
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/idelchi/godyl/internal/defaults"
+	"github.com/idelchi/godyl/internal/tools"
+)
+
+// Severity represents how serious a finding is.
+type Severity int
+
+const (
+	// Info indicates a finding that is worth knowing about, but most likely harmless.
+	Info Severity = iota
+	// Warning indicates a finding that is likely a mistake.
+	Warning
+	// Error indicates a finding that will fail or have no effect at runtime.
+	Error
+)
+
+// Finding is a single problem reported by a rule.
+type Finding struct {
+	// File is the tools file the finding belongs to.
+	File string `json:"file,omitempty"`
+	// Tool is the name of the tool the finding belongs to, if any.
+	Tool string `json:"tool,omitempty"`
+	// Rule is the name of the rule that produced the finding.
+	Rule string `json:"rule"`
+	// Message describes the problem.
+	Message string `json:"message"`
+	// Index is the position of the tool in the file (0-based), or -1 if the finding is not tool specific.
+	Index int `json:"index"`
+	// Severity of the finding.
+	Severity Severity `json:"severity"`
+	// Fixable indicates that the finding can be fixed automatically.
+	Fixable bool `json:"fixable"`
+	// Fixed indicates that the finding was fixed automatically.
+	Fixed bool `json:"fixed"`
+
+	fix fix
+}
+
+// Findings is a collection of findings.
+type Findings []Finding
+
+// AtLeast returns the findings with a severity of at least the given level.
+func (f Findings) AtLeast(severity Severity) Findings {
+	var filtered Findings
+
+	for _, finding := range f {
+		if finding.Severity >= severity {
+			filtered = append(filtered, finding)
+		}
+	}
+
+	return filtered
+}
+
+// Unfixed returns the findings that have not been fixed.
+func (f Findings) Unfixed() Findings {
+	var filtered Findings
+
+	for _, finding := range f {
+		if !finding.Fixed {
+			filtered = append(filtered, finding)
+		}
+	}
+
+	return filtered
+}
+
+// Sort orders the findings by file, tool position, severity (highest first), rule and message.
+func (f Findings) Sort() {
+	slices.SortStableFunc(f, func(a, b Finding) int {
+		return cmp.Or(
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Index, b.Index),
+			cmp.Compare(b.Severity, a.Severity),
+			cmp.Compare(a.Rule, b.Rule),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+}
+
+// Input holds everything the rules inspect.
+type Input struct {
+	// Raw are the tools as written in the tools file.
+	Raw tools.Tools
+	// Resolved are the same tools, in the same order, after inheriting from the defaults.
+	Resolved tools.Tools
+	// Defaults are the (unresolved) user provided defaults.
+	// Rules concerning the defaults are skipped if nil.
+	Defaults *defaults.Defaults
+	// Inherit is the default to inherit from when unset in a tool.
+	Inherit string
+}
+
+// Rule inspects the input and reports findings.
+type Rule struct {
+	// Check performs the inspection.
+	Check func(in Input) Findings
+	// Name is the identifier of the rule.
+	Name string
+}
+
+// Run applies the rules (or all rules if none are given) to the input.
+func Run(in Input, rules ...Rule) Findings {
+	if len(rules) == 0 {
+		rules = Rules()
+	}
+
+	var findings Findings
+
+	for _, rule := range rules {
+		for _, finding := range rule.Check(in) {
+			finding.Rule = rule.Name
+
+			findings = append(findings, finding)
+		}
+	}
+
+	findings.Sort()
+
+	return findings
+}
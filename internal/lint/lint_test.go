@@ -0,0 +1,290 @@
+package lint_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/defaults"
+	"github.com/idelchi/godyl/internal/lint"
+	"github.com/idelchi/godyl/internal/tools"
+	"github.com/idelchi/godyl/pkg/unmarshal"
+)
+
+// input unmarshals the tools twice, using the second copy as the resolved tools.
+func input(t *testing.T, data string) lint.Input {
+	t.Helper()
+
+	var raw, resolved tools.Tools
+
+	if err := unmarshal.Strict([]byte(data), &raw); err != nil {
+		t.Fatalf("unmarshalling tools: %v", err)
+	}
+
+	if err := unmarshal.Strict([]byte(data), &resolved); err != nil {
+		t.Fatalf("unmarshalling tools: %v", err)
+	}
+
+	return lint.Input{Raw: raw, Resolved: resolved}
+}
+
+// rules returns the rule names of the findings.
+func rules(findings lint.Findings) []string {
+	names := make([]string, 0, len(findings))
+
+	for _, finding := range findings {
+		names = append(names, finding.Rule)
+	}
+
+	return names
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		tools string
+		want  []string
+	}{
+		{
+			name: "clean tool",
+			tools: `
+- name: idelchi/godyl
+  source:
+    type: github
+  hints:
+    - pattern: "*linux*"
+  exe:
+    patterns: ["^{{ .Exe }}$"]
+`,
+			want: []string{},
+		},
+		{
+			name: "hint with slash",
+			tools: `
+- name: idelchi/godyl
+  hints:
+    - pattern: "linux/amd64"
+`,
+			want: []string{"hint-never-matches"},
+		},
+		{
+			name: "invalid regex hint",
+			tools: `
+- name: idelchi/godyl
+  hints:
+    - pattern: "(linux"
+      type: regex
+`,
+			want: []string{"hint-never-matches"},
+		},
+		{
+			name: "templated hint is skipped",
+			tools: `
+- name: idelchi/godyl
+  hints:
+    - pattern: "{{ .OS }}/{{ .ARCH }}"
+`,
+			want: []string{},
+		},
+		{
+			name: "required and excluded hint",
+			tools: `
+- name: idelchi/godyl
+  hints:
+    - pattern: "*linux*"
+      match: required
+    - pattern: "*linux*"
+      match: excluded
+`,
+			want: []string{"hint-never-matches"},
+		},
+		{
+			name: "fallback repeats source",
+			tools: `
+- name: idelchi/godyl
+  source:
+    type: github
+  fallbacks: [github, go]
+`,
+			want: []string{"fallback-repeats-source"},
+		},
+		{
+			name: "checksum entry without url or path",
+			tools: `
+- name: idelchi/godyl
+  checksum:
+    entry: godyl.tar.gz
+`,
+			want: []string{"checksum-entry"},
+		},
+		{
+			name: "checksum entry with url",
+			tools: `
+- name: idelchi/godyl
+  checksum:
+    value: url:https://example.com/checksums.txt
+    entry: godyl.tar.gz
+`,
+			want: []string{},
+		},
+		{
+			name: "unknown values key",
+			tools: `
+- name: idelchi/godyl
+  values:
+    known: value
+  output: "{{ .Values.known }}/{{ .Values.unknown }}"
+`,
+			want: []string{"unknown-values-key"},
+		},
+		{
+			name: "exe pattern without exe",
+			tools: `
+- name: idelchi/godyl
+  exe:
+    patterns: ["godyl"]
+`,
+			want: []string{"exe-pattern-without-exe"},
+		},
+		{
+			name: "identical tools",
+			tools: `
+- name: idelchi/godyl
+- name: idelchi/godyl
+`,
+			want: []string{"duplicate-tool"},
+		},
+		{
+			name: "same output",
+			tools: `
+- name: idelchi/godyl
+  source:
+    type: github
+- name: other/godyl
+  source:
+    type: github
+`,
+			want: []string{"duplicate-output"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := rules(lint.Run(input(t, tc.tools)))
+
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("Run() rules = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunUnusedDefault(t *testing.T) {
+	t.Parallel()
+
+	defs, err := defaults.NewDefaultsFromBytes([]byte(`
+default:
+  output: ~/.local/bin
+base:
+  inherit: default
+used:
+  inherit: base
+unused:
+  inherit: default
+`))
+	if err != nil {
+		t.Fatalf("loading defaults: %v", err)
+	}
+
+	in := input(t, `
+- name: idelchi/godyl
+  inherit: used
+- name: idelchi/envprof
+`)
+	in.Defaults = defs
+	in.Inherit = "default"
+
+	findings := lint.Run(in)
+
+	if len(findings) != 1 || !strings.Contains(findings[0].Message, `"unused"`) {
+		t.Errorf("Run() = %v, want a single finding for default %q", findings, "unused")
+	}
+}
+
+func TestFindings(t *testing.T) {
+	t.Parallel()
+
+	findings := lint.Findings{
+		{Rule: "a", Severity: lint.Info},
+		{Rule: "b", Severity: lint.Warning, Fixed: true},
+		{Rule: "c", Severity: lint.Error},
+	}
+
+	if got := rules(findings.AtLeast(lint.Warning)); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("AtLeast(Warning) = %v, want [b c]", got)
+	}
+
+	if got := rules(findings.Unfixed()); !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("Unfixed() = %v, want [a c]", got)
+	}
+}
+
+func TestFix(t *testing.T) {
+	t.Parallel()
+
+	data := `# Tools
+- name: idelchi/godyl
+  source:
+    type: github
+  # Fallbacks
+  fallbacks:
+    - github
+    - go
+# Duplicate
+- name: idelchi/godyl
+  source:
+    type: github
+  fallbacks:
+    - github
+    - go
+# Keep me
+- name: idelchi/envprof
+`
+
+	findings := lint.Run(input(t, data))
+
+	fixed, findings, err := lint.Fix([]byte(data), findings)
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+
+	if unfixed := findings.Unfixed(); len(unfixed) != 0 {
+		t.Errorf("Fix() left unfixed findings: %v", unfixed)
+	}
+
+	want := `# Tools
+- name: idelchi/godyl
+  source:
+    type: github
+  # Fallbacks
+  fallbacks:
+    - go
+# Keep me
+- name: idelchi/envprof
+`
+
+	if string(fixed) != want {
+		t.Errorf("Fix() =\n%s\nwant\n%s", fixed, want)
+	}
+
+	// The fixed contents must no longer produce fixable findings.
+	for _, finding := range lint.Run(input(t, string(fixed))) {
+		if finding.Fixable {
+			t.Errorf("fixed contents still have fixable finding: %v", finding)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+package lint
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/idelchi/godyl/internal/tools/checksum"
+	"github.com/idelchi/godyl/internal/tools/fallbacks"
+	"github.com/idelchi/godyl/internal/tools/hints"
+	"github.com/idelchi/godyl/internal/tools/sources"
+	"github.com/idelchi/godyl/internal/tools/sources/install"
+	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/pkg/pretty"
+)
+
+// Rules returns all available rules.
+func Rules() []Rule {
+	return []Rule{
+		{Name: "hint-never-matches", Check: hintNeverMatches},
+		{Name: "fallback-repeats-source", Check: fallbackRepeatsSource},
+		{Name: "checksum-entry", Check: checksumEntry},
+		{Name: "unknown-values-key", Check: unknownValuesKey},
+		{Name: "exe-pattern-without-exe", Check: exePatternWithoutExe},
+		{Name: "duplicate-tool", Check: duplicateTool},
+		{Name: "duplicate-output", Check: duplicateOutput},
+		{Name: "unused-default", Check: unusedDefault},
+	}
+}
+
+// isTemplated returns true if the string contains a template action,
+// in which case its final value can only be known at runtime.
+func isTemplated(s string) bool {
+	return strings.Contains(s, "{{")
+}
+
+// newFinding creates a finding for the tool at the given index.
+func newFinding(index int, t *tool.Tool, severity Severity, format string, args ...any) Finding {
+	return Finding{
+		Index:    index,
+		Tool:     t.Name,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// hintNeverMatches reports hints whose pattern can never match an asset name.
+func hintNeverMatches(in Input) Findings {
+	var findings Findings
+
+	for i, t := range in.Raw {
+		if !t.Hints.Has() {
+			continue
+		}
+
+		byMatch := map[hints.Match][]string{}
+
+		for _, hint := range *t.Hints {
+			if isTemplated(hint.Pattern) || hint.Pattern == "" {
+				continue
+			}
+
+			if hint.Type == "" {
+				hint.Type = hints.Glob
+			}
+
+			if reason := neverMatches(hint); reason != "" {
+				findings = append(findings, newFinding(i, t, Error, "hint %q can never match: %s", hint.Pattern, reason))
+			}
+
+			if !isTemplated(hint.Match.Template) {
+				match := hints.Match(hint.Match.Template)
+
+				byMatch[match] = append(byMatch[match], string(hint.Type)+":"+hint.Pattern)
+			}
+		}
+
+		for _, required := range byMatch[hints.Required] {
+			if slices.Contains(byMatch[hints.Excluded], required) {
+				_, pattern, _ := strings.Cut(required, ":")
+
+				findings = append(findings, newFinding(i, t, Error,
+					"hint %q is both required and excluded, no asset can satisfy both", pattern))
+			}
+		}
+	}
+
+	return findings
+}
+
+// neverMatches returns a reason for why the hint can never match, or an empty string if it can.
+func neverMatches(hint hints.Hint) string {
+	switch hint.Type {
+	case hints.Regex:
+		if _, err := regexp.Compile(hint.Pattern); err != nil {
+			return fmt.Sprintf("invalid regular expression: %v", err)
+		}
+
+		return ""
+	case hints.Glob:
+		if _, err := path.Match(hint.Pattern, ""); err != nil {
+			return fmt.Sprintf("invalid glob pattern: %v", err)
+		}
+	case hints.GlobStar:
+		if !doublestar.ValidatePattern(hint.Pattern) {
+			return "invalid globstar pattern"
+		}
+	case hints.StartsWith, hints.EndsWith, hints.Contains:
+	default:
+		return fmt.Sprintf("unknown hint type %q", hint.Type)
+	}
+
+	if strings.Contains(hint.Pattern, "/") {
+		return "asset names never contain '/'"
+	}
+
+	return ""
+}
+
+// fallbackRepeatsSource reports fallbacks that repeat the primary source or each other.
+func fallbackRepeatsSource(in Input) Findings {
+	var findings Findings
+
+	for i, t := range in.Resolved {
+		primary := t.Source.Type
+
+		if isTemplated(primary.String()) || len(t.Fallbacks) == 0 {
+			continue
+		}
+
+		var repeated []sources.Type
+
+		seen := map[sources.Type]bool{primary: true}
+
+		for _, fallback := range t.Fallbacks {
+			if seen[fallback] {
+				repeated = append(repeated, fallback)
+			}
+
+			seen[fallback] = true
+		}
+
+		if len(repeated) == 0 {
+			continue
+		}
+
+		finding := newFinding(i, t, Warning,
+			"fallbacks %v repeat the primary source %q or each other and will never be tried", repeated, primary)
+
+		// Only fallbacks set by the tool itself can be fixed in the tools file.
+		if raw := in.Raw[i]; len(raw.Fallbacks) > 0 {
+			finding.Fixable = true
+			finding.fix = fix{
+				kind:      fixFallbacks,
+				fallbacks: fallbacks.Fallbacks(t.Fallbacks.Build(primary)[1:]),
+			}
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// checksumEntry reports `checksum.entry` values that are ignored or rejected at runtime.
+func checksumEntry(in Input) Findings {
+	var findings Findings
+
+	for i, t := range in.Raw {
+		entry, value := t.Checksum.Entry, t.Checksum.Value
+
+		if entry == "" || isTemplated(entry) || isTemplated(value) {
+			continue
+		}
+
+		if !strings.HasPrefix(value, "url:") && !strings.HasPrefix(value, "path:") {
+			findings = append(findings, newFinding(i, t, Warning,
+				"checksum.entry %q is ignored unless checksum.value uses 'url:' or 'path:'", entry))
+
+			continue
+		}
+
+		if resolved := in.Resolved[i].Checksum.Type; resolved == checksum.File {
+			findings = append(findings, newFinding(i, t, Error,
+				"checksum.entry %q cannot be used with checksum.type 'file', set an algorithm such as 'sha256'", entry))
+		}
+	}
+
+	return findings
+}
+
+var (
+	// reValuesField matches `.Values.key` template references.
+	reValuesField = regexp.MustCompile(`\.Values\.([A-Za-z_][A-Za-z0-9_]*)`)
+	// reValuesIndex matches `index .Values "key"` template references.
+	reValuesIndex = regexp.MustCompile(`index\s+\.Values\s+"([^"]+)"`)
+	// reExe matches `.Exe` template references.
+	reExe = regexp.MustCompile(`\.Exe\b`)
+)
+
+// unknownValuesKey reports template references to keys that are not defined in `values`.
+func unknownValuesKey(in Input) Findings {
+	var findings Findings
+
+	for i, t := range in.Resolved {
+		rendered := pretty.YAML(t)
+
+		var unknown []string
+
+		for _, re := range []*regexp.Regexp{reValuesField, reValuesIndex} {
+			for _, match := range re.FindAllStringSubmatch(rendered, -1) {
+				key := match[1]
+
+				if _, ok := t.Values[key]; !ok && !slices.Contains(unknown, key) {
+					unknown = append(unknown, key)
+				}
+			}
+		}
+
+		for _, key := range unknown {
+			findings = append(findings, newFinding(i, t, Error,
+				"template references '.Values.%s', but %q is not defined in values", key, key))
+		}
+	}
+
+	return findings
+}
+
+// exePatternWithoutExe reports `exe.patterns` that do not reference `{{ .Exe }}`.
+func exePatternWithoutExe(in Input) Findings {
+	var findings Findings
+
+	for i, t := range in.Raw {
+		if t.Exe.Patterns == nil {
+			continue
+		}
+
+		for _, pattern := range *t.Exe.Patterns {
+			if !reExe.MatchString(pattern) {
+				findings = append(findings, newFinding(i, t, Info,
+					"exe.patterns entry %q does not reference '{{ .Exe }}' and will not follow changes to exe.name",
+					pattern))
+			}
+		}
+	}
+
+	return findings
+}
+
+// duplicateTool reports tools that are declared more than once.
+func duplicateTool(in Input) Findings {
+	var findings Findings
+
+	first := map[string]int{}
+
+	for i, t := range in.Raw {
+		if t.Name == "" || isTemplated(t.Name) {
+			continue
+		}
+
+		j, ok := first[t.Name]
+		if !ok {
+			first[t.Name] = i
+
+			continue
+		}
+
+		if reflect.DeepEqual(in.Raw[j], t) {
+			finding := newFinding(i, t, Warning, "identical to tool #%d", j+1)
+			finding.Fixable = true
+			finding.fix = fix{kind: fixDuplicate}
+
+			findings = append(findings, finding)
+
+			continue
+		}
+
+		findings = append(findings, newFinding(i, t, Info,
+			"name is also used by tool #%d, make sure tags or skip conditions distinguish them", j+1))
+	}
+
+	return findings
+}
+
+// duplicateOutput reports tools that install an executable to the same path.
+func duplicateOutput(in Input) Findings {
+	var findings Findings
+
+	first := map[string]int{}
+
+	for i, t := range in.Resolved {
+		target := path.Join(t.Output, exeName(t))
+
+		j, ok := first[target]
+		if !ok {
+			first[target] = i
+
+			continue
+		}
+
+		// Identical declarations are reported by `duplicate-tool`.
+		if reflect.DeepEqual(in.Raw[i], in.Raw[j]) {
+			continue
+		}
+
+		findings = append(findings, newFinding(i, t, Warning,
+			"installs to %q, as does tool #%d (%s)", target, j+1, in.Resolved[j].Name))
+	}
+
+	return findings
+}
+
+// exeName returns the name of the executable as it will be resolved at runtime.
+func exeName(t *tool.Tool) string {
+	if t.Exe.Name != "" {
+		return t.Exe.Name
+	}
+
+	switch t.Source.Type {
+	case sources.GITHUB, sources.GITLAB:
+		if _, repo, err := install.SplitName(t.Name); err == nil {
+			return repo
+		}
+	case sources.GO:
+		return path.Base(t.Name)
+	case sources.URL, sources.NONE:
+	}
+
+	return t.Name
+}
+
+// unusedDefault reports defaults that are not inherited by any tool.
+func unusedDefault(in Input) Findings {
+	if in.Defaults == nil {
+		return nil
+	}
+
+	used := map[string]bool{}
+
+	var visit func(name string)
+
+	visit = func(name string) {
+		if used[name] {
+			return
+		}
+
+		used[name] = true
+
+		if d := in.Defaults.Get(name); d != nil && d.Inherit != nil {
+			for _, parent := range *d.Inherit {
+				visit(parent)
+			}
+		}
+	}
+
+	for _, t := range in.Raw {
+		if t.Inherit == nil {
+			visit(in.Inherit)
+
+			continue
+		}
+
+		for _, name := range *t.Inherit {
+			visit(name)
+		}
+	}
+
+	var findings Findings
+
+	for name := range *in.Defaults {
+		if !used[name] {
+			findings = append(findings, Finding{
+				Index:    -1,
+				Severity: Info,
+				Message:  fmt.Sprintf("default %q is not inherited by any tool", name),
+			})
+		}
+	}
+
+	return findings
+}
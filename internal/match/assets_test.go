@@ -486,3 +486,109 @@ func TestResultsErrors(t *testing.T) {
 		}
 	})
 }
+
+// TestAssetsSelectArchLevel verifies that assets built for a higher x86-64 level than the host
+// are disqualified, and that the highest compatible level is preferred.
+func TestAssetsSelectArchLevel(t *testing.T) {
+	t.Parallel()
+
+	names := []string{
+		"tool_linux_amd64.tar.gz",
+		"tool_linux_amd64v2.tar.gz",
+		"tool_linux_amd64v3.tar.gz",
+		"tool_linux_amd64v4.tar.gz",
+	}
+
+	tests := []struct {
+		name string
+		host string
+		want string
+	}{
+		{name: "baseline host", host: "amd64", want: "tool_linux_amd64.tar.gz"},
+		{name: "v3 host", host: "amd64v3", want: "tool_linux_amd64v3.tar.gz"},
+		{name: "v4 host", host: "x86_64_v4", want: "tool_linux_amd64v4.tar.gz"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var req match.Requirements
+
+			req.Platform.OS.Name = "linux"
+			req.Platform.Architecture.Name = tc.host
+
+			if err := req.Platform.Parse(); err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+
+			as := match.Assets{}.FromNames(names...)
+			for i := range as {
+				as[i].Parse()
+			}
+
+			results := as.Select(req)
+			if len(results) != 1 || results[0].Asset.Name != tc.want {
+				t.Errorf("Select() = %v, want %q", results, tc.want)
+			}
+		})
+	}
+}
+
+func TestAssetsSelectGlibc(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		names []string
+		host  string
+		want  string
+	}{
+		{
+			name:  "older glibc build",
+			names: []string{"tool-linux-amd64-gnu-2.17.tar.gz", "tool-linux-amd64-gnu-2.35.tar.gz"},
+			host:  "2.28",
+			want:  "tool-linux-amd64-gnu-2.17.tar.gz",
+		},
+		{
+			name:  "musl build for too old glibc",
+			names: []string{"tool-linux-amd64-gnu-2.35.tar.gz", "tool-linux-amd64-musl.tar.gz"},
+			host:  "2.28",
+			want:  "tool-linux-amd64-musl.tar.gz",
+		},
+		{
+			name:  "glibc build for recent glibc",
+			names: []string{"tool-linux-amd64-gnu-2.35.tar.gz", "tool-linux-amd64-musl.tar.gz"},
+			host:  "2.36",
+			want:  "tool-linux-amd64-gnu-2.35.tar.gz",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var req match.Requirements
+
+			req.Platform.OS.Name = "linux"
+			req.Platform.Architecture.Name = "amd64"
+			req.Platform.Library.Name = "gnu"
+
+			if err := req.Platform.Parse(); err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+
+			req.Platform.Library.SetVersion(tc.host)
+
+			as := match.Assets{}.FromNames(tc.names...)
+			for i := range as {
+				as[i].Parse()
+			}
+
+			results := as.Select(req)
+			if len(results) != 1 || results[0].Asset.Name != tc.want {
+				t.Errorf("Select() = %v, want %q", results, tc.want)
+			}
+		})
+	}
+}
@@ -52,20 +52,27 @@ func (m Results) ToString() string {
 }
 
 // Best returns the best qualified results based on the highest score.
-// If multiple results have the same best score, they are all returned.
+// Of results with the same best score, those built for the highest x86-64 level are preferred.
+// If multiple results remain, they are all returned.
 func (m Results) Best() Results {
 	var best Results
 
-	var bestScore int
+	var bestScore, bestLevel int
 
 	for _, result := range m {
-		if result.Qualified {
-			if result.Score > bestScore {
-				best = Results{result}
-				bestScore = result.Score
-			} else if result.Score == bestScore {
-				best = append(best, result)
-			}
+		if !result.Qualified {
+			continue
+		}
+
+		level := result.Asset.Platform.Architecture.Level()
+
+		switch {
+		case result.Score > bestScore, result.Score == bestScore && level > bestLevel:
+			best = Results{result}
+			bestScore = result.Score
+			bestLevel = level
+		case result.Score == bestScore && level == bestLevel:
+			best = append(best, result)
 		}
 	}
 
@@ -0,0 +1,51 @@
+// Package metadata caches the release metadata retrieved from the APIs of the sources and their web fallbacks,
+// such that unchanged metadata does not count against the rate limits on subsequent runs.
+package metadata
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/idelchi/godyl/pkg/httpcache"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+// cache is the metadata cache in use, nil while caching is disabled.
+//
+//nolint:gochecknoglobals	// The cache is shared by all clients of the process.
+var cache atomic.Pointer[httpcache.Cache]
+
+// Enable caches the metadata in dir, serving it without revalidation for ttl.
+func Enable(dir folder.Folder, ttl time.Duration) {
+	cache.Store(httpcache.New(dir, ttl))
+}
+
+// Disable stops caching the metadata.
+func Disable() {
+	cache.Store(nil)
+}
+
+// Transport wraps base with the metadata cache in use at the time of each request.
+// A nil base uses http.DefaultTransport.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return roundTripper(func(req *http.Request) (*http.Response, error) {
+		if c := cache.Load(); c != nil {
+			return c.Transport(base).RoundTrip(req)
+		}
+
+		return base.RoundTrip(req)
+	})
+}
+
+// roundTripper adapts a function to an http.RoundTripper.
+type roundTripper func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls the function.
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
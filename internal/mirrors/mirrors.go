@@ -0,0 +1,28 @@
+// Package mirrors holds the mirror rules applied to all downloads of the process,
+// such as the assets of the tools, checksum files and Go toolchains.
+package mirrors
+
+import (
+	"sync/atomic"
+
+	"github.com/idelchi/godyl/pkg/mirror"
+)
+
+// rules are the mirror rules in use.
+//
+//nolint:gochecknoglobals	// The rules are shared by all downloads of the process.
+var rules atomic.Pointer[mirror.Rules]
+
+// Set applies the rules to all subsequent downloads.
+func Set(r mirror.Rules) {
+	rules.Store(&r)
+}
+
+// Rules returns the rules in use, which are empty unless set.
+func Rules() mirror.Rules {
+	if r := rules.Load(); r != nil {
+		return *r
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+// Package network holds the HTTP transport shared by all requests of the process,
+// such as the API requests of the sources, the downloads and the update checks,
+// such that the certificate authorities, proxies, timeouts and retries are configured in one place
+// and connections are reused across tools.
+package network
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/idelchi/godyl/pkg/transport"
+)
+
+const (
+	// defaultRetries is the number of retries until configured.
+	defaultRetries = 3
+	// defaultRetryWait is the initial back-off between retries until configured.
+	defaultRetryWait = time.Second
+	// retryWaitMax is the maximum back-off between retries, unless the initial one is longer.
+	retryWaitMax = 30 * time.Second
+)
+
+//nolint:gochecknoglobals	// The transport is shared by all clients of the process.
+var (
+	// base is the configured transport, nil until configured.
+	base atomic.Pointer[http.Transport]
+	// insecure is the configured transport skipping TLS verification, nil until requested.
+	insecure atomic.Pointer[http.Transport]
+	// policy is the retry policy of failed requests, nil until configured.
+	policy atomic.Pointer[Policy]
+)
+
+// Configure builds the transport for all subsequent requests from the options,
+// retrying failed requests up to retries times, initially waiting wait between them.
+func Configure(opts transport.Options, retries int, wait time.Duration) error {
+	t, err := transport.New(opts)
+	if err != nil {
+		return err
+	}
+
+	if old := base.Swap(t); old != nil {
+		old.CloseIdleConnections()
+	}
+
+	if old := insecure.Swap(nil); old != nil {
+		old.CloseIdleConnections()
+	}
+
+	p := NewPolicy(retries, wait)
+	policy.Store(&p)
+
+	return nil
+}
+
+// Transport returns the configured transport, or one with the default settings if not configured.
+func Transport() *http.Transport {
+	if t := base.Load(); t != nil {
+		return t
+	}
+
+	t, _ := transport.New(transport.Options{})
+
+	base.CompareAndSwap(nil, t)
+
+	return base.Load()
+}
+
+// Insecure returns the configured transport with TLS verification disabled.
+// It is built once, such that its connections are reused across tools like those of the transport.
+func Insecure() *http.Transport {
+	if t := insecure.Load(); t != nil {
+		return t
+	}
+
+	t := Transport().Clone()
+
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	t.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec	// Only set if explicitly requested
+
+	insecure.CompareAndSwap(nil, t)
+
+	return insecure.Load()
+}
+
+// TransportFor returns the transport for requests skipping TLS verification or not.
+func TransportFor(noVerifySSL bool) *http.Transport {
+	if noVerifySSL {
+		return Insecure()
+	}
+
+	return Transport()
+}
+
+// Retries returns the number of retries of failed requests.
+func Retries() int {
+	return configured().Retries
+}
+
+// configured returns the configured retry policy, or the default one if not configured.
+func configured() Policy {
+	if p := policy.Load(); p != nil {
+		return *p
+	}
+
+	return NewPolicy(defaultRetries, defaultRetryWait)
+}
+
+// Policy is the retry policy of requests.
+type Policy struct {
+	// Retries is the number of retries of failed requests.
+	Retries int
+	// WaitMin and WaitMax bound the exponential back-off between retries.
+	WaitMin time.Duration
+	WaitMax time.Duration
+}
+
+// NewPolicy returns the policy retrying failed requests up to retries times,
+// initially waiting wait between them and doubling the wait for each retry.
+func NewPolicy(retries int, wait time.Duration) Policy {
+	if wait <= 0 {
+		wait = defaultRetryWait
+	}
+
+	return Policy{
+		Retries: max(retries, 0),
+		WaitMin: wait,
+		WaitMax: max(wait, retryWaitMax),
+	}
+}
+
+// policyKey is the context key of the retry policy.
+type policyKey struct{}
+
+// WithPolicy returns a context whose requests are retried with the policy instead of the configured one.
+func WithPolicy(ctx context.Context, p Policy) context.Context {
+	return context.WithValue(ctx, policyKey{}, p)
+}
+
+// PolicyFrom returns the retry policy of the requests of the context,
+// which is the configured one unless set with WithPolicy.
+func PolicyFrom(ctx context.Context) Policy {
+	if p, ok := ctx.Value(policyKey{}).(Policy); ok {
+		return p
+	}
+
+	return configured()
+}
+
+// Retrying returns the transport retrying failed requests with the retry policy of their context, for the API requests.
+func Retrying() http.RoundTripper {
+	return retrying{}
+}
+
+// RetryingFor returns the transport retrying failed requests, skipping TLS verification or not.
+func RetryingFor(noVerifySSL bool) http.RoundTripper {
+	return retrying{insecure: noVerifySSL}
+}
+
+// retrying retries failed requests with the retry policy of their context.
+type retrying struct {
+	// insecure sends the requests with the transport skipping TLS verification.
+	insecure bool
+}
+
+// RoundTrip sends the request with the transport, retrying it with the retry policy of its context.
+func (r retrying) RoundTrip(req *http.Request) (*http.Response, error) {
+	p := PolicyFrom(req.Context())
+
+	return transport.Retry(TransportFor(r.insecure), p.Retries, p.WaitMin, p.WaitMax).RoundTrip(req)
+}
@@ -0,0 +1,87 @@
+package network_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/idelchi/godyl/internal/network"
+)
+
+func TestNewPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		retries int
+		wait    time.Duration
+		want    network.Policy
+	}{
+		{
+			name:    "default wait",
+			retries: 3,
+			want:    network.Policy{Retries: 3, WaitMin: time.Second, WaitMax: 30 * time.Second},
+		},
+		{
+			name:    "short wait",
+			retries: 1,
+			wait:    100 * time.Millisecond,
+			want:    network.Policy{Retries: 1, WaitMin: 100 * time.Millisecond, WaitMax: 30 * time.Second},
+		},
+		{
+			name: "long wait",
+			wait: time.Minute,
+			want: network.Policy{Retries: 0, WaitMin: time.Minute, WaitMax: time.Minute},
+		},
+		{
+			name:    "negative retries",
+			retries: -1,
+			want:    network.Policy{Retries: 0, WaitMin: time.Second, WaitMax: 30 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := network.NewPolicy(tt.retries, tt.wait); got != tt.want {
+				t.Errorf("NewPolicy(%d, %s) = %+v, want %+v", tt.retries, tt.wait, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyFrom(t *testing.T) {
+	t.Parallel()
+
+	if got := network.PolicyFrom(t.Context()); got.Retries != network.Retries() {
+		t.Errorf("PolicyFrom() retries = %d, want the configured %d", got.Retries, network.Retries())
+	}
+
+	want := network.NewPolicy(7, 5*time.Second)
+
+	if got := network.PolicyFrom(network.WithPolicy(t.Context(), want)); got != want {
+		t.Errorf("PolicyFrom(WithPolicy()) = %+v, want %+v", got, want)
+	}
+}
+
+func TestTransportFor(t *testing.T) {
+	t.Parallel()
+
+	secure := network.TransportFor(false)
+	if secure != network.Transport() {
+		t.Error("TransportFor(false) is not the shared transport")
+	}
+
+	if c := secure.TLSClientConfig; c != nil && c.InsecureSkipVerify {
+		t.Error("TransportFor(false) skips TLS verification")
+	}
+
+	insecure := network.TransportFor(true)
+	if insecure != network.TransportFor(true) {
+		t.Error("TransportFor(true) builds a new transport per call")
+	}
+
+	if c := insecure.TLSClientConfig; c == nil || !c.InsecureSkipVerify {
+		t.Error("TransportFor(true) verifies TLS")
+	}
+}
@@ -0,0 +1,113 @@
+package presentation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	"github.com/idelchi/godyl/internal/processor"
+	"github.com/idelchi/godyl/internal/tools/drift"
+	"github.com/idelchi/godyl/pkg/logger"
+)
+
+// ShowDrift displays the states of the installed tools, followed by the errors of the tools that could not be checked.
+func ShowDrift(reports []drift.Report, summary processor.Summary, cfg ShowConfig, log *logger.Logger) {
+	if len(reports) == 0 {
+		log.Info("Nothing of interest to show")
+	} else {
+		log.Info("")
+		log.Info(RenderDrift(reports))
+		log.Info(FormatDriftCounts(reports))
+	}
+
+	if len(summary.Errors) > 0 {
+		showErrors(summary, cfg, log)
+	}
+}
+
+// RenderDrift renders the states of the installed tools as a table, the most severe first.
+func RenderDrift(reports []drift.Report) string {
+	const maxWidth = 100
+
+	reports = slices.Clone(reports)
+	slices.SortStableFunc(reports, func(a, b drift.Report) int {
+		if order := slices.Index(drift.States, a.State) - slices.Index(drift.States, b.State); order != 0 {
+			return order
+		}
+
+		return strings.Compare(a.Tool, b.Tool)
+	})
+
+	t := table.NewWriter()
+
+	header := table.Row{"Tool", "Installed", "Target", "Path", "State", "Details"}
+
+	t.AppendHeader(header)
+
+	columns := make([]table.ColumnConfig, 0, len(header))
+	for i := range header {
+		columns = append(columns, table.ColumnConfig{Number: i + 1, WidthMax: maxWidth})
+	}
+
+	t.SetColumnConfigs(columns)
+	t.SetStyle(table.StyleRounded)
+
+	t.Style().Color.Header = text.Colors{text.FgBlue, text.Bold}
+
+	for _, report := range reports {
+		colors := driftColors(report.State)
+
+		t.AppendRow(table.Row{
+			report.Tool,
+			report.Installed,
+			report.Target,
+			report.Path,
+			colors.Sprint(report.State),
+			report.Message,
+		})
+	}
+
+	return t.Render()
+}
+
+// FormatDriftCounts formats the number of tools in each state, the most severe first.
+func FormatDriftCounts(reports []drift.Report) string {
+	counts := make([]string, 0, len(drift.States))
+
+	for _, state := range drift.States {
+		count := 0
+
+		for _, report := range reports {
+			if report.State == state {
+				count++
+			}
+		}
+
+		if count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count, state))
+		}
+	}
+
+	return fmt.Sprintf("%d tools checked: %s", len(reports), strings.Join(counts, ", "))
+}
+
+// driftColors returns the colors for a state.
+func driftColors(state drift.State) text.Colors {
+	switch state {
+	case drift.Missing:
+		return text.Colors{text.FgRed}
+	case drift.Modified:
+		return text.Colors{text.FgHiRed}
+	case drift.Unmanaged:
+		return text.Colors{text.FgMagenta}
+	case drift.Unknown, drift.Outdated:
+		return text.Colors{text.FgYellow}
+	case drift.UpToDate:
+		return text.Colors{text.FgGreen}
+	default:
+		return nil
+	}
+}
@@ -64,10 +64,18 @@ func (f *ErrorFormatter) FormatSummary(summary processor.Summary) string {
 		parts = append(parts, fmt.Sprintf("%d failed", summary.Failed))
 	}
 
+	if summary.TimedOut > 0 {
+		parts = append(parts, fmt.Sprintf("%d timed out", summary.TimedOut))
+	}
+
 	if summary.Skipped > 0 {
 		parts = append(parts, fmt.Sprintf("%d skipped", summary.Skipped))
 	}
 
+	if summary.Interrupted > 0 {
+		parts = append(parts, fmt.Sprintf("%d interrupted", summary.Interrupted))
+	}
+
 	if len(parts) == 0 {
 		return "No tools processed"
 	}
@@ -0,0 +1,273 @@
+package presentation
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/processor"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+// ReportFormatter renders the results of a run as a report.
+type ReportFormatter struct {
+	// format is one of the report formats of the configuration, such as root.ReportJUnit.
+	format string
+}
+
+// NewReportFormatter creates a new report formatter.
+func NewReportFormatter(format string) *ReportFormatter {
+	return &ReportFormatter{
+		format: format,
+	}
+}
+
+// Format renders the summary in the format of the formatter.
+func (f *ReportFormatter) Format(summary processor.Summary) (string, error) {
+	switch f.format {
+	case root.ReportJUnit:
+		return f.formatJUnit(summary)
+	case root.ReportMarkdown:
+		return f.formatMarkdown(summary), nil
+	default:
+		return "", fmt.Errorf("unknown report format %q", f.format)
+	}
+}
+
+// WriteReports renders the summary into the report files, keyed by their format.
+func WriteReports(summary processor.Summary, reports map[string]file.File) error {
+	var errs []error
+
+	for format, path := range reports {
+		report, err := NewReportFormatter(format).Format(summary)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if err := path.Write([]byte(report)); err != nil {
+			errs = append(errs, fmt.Errorf("writing %s report: %w", format, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// junitSuites is the root element of a JUnit XML report.
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+// junitSuite is a suite of test cases of a JUnit XML report.
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+// junitCase is a test case of a JUnit XML report, one per tool.
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Skipped   *junitMessage `xml:"skipped"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitMessage is the outcome of a test case that did not pass.
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Details string `xml:",chardata"`
+}
+
+// formatJUnit renders the summary as JUnit XML.
+// Failed and timed out tools are reported as failures, interrupted ones as errors.
+func (f *ReportFormatter) formatJUnit(summary processor.Summary) (string, error) {
+	suite := junitSuite{
+		Name:      "godyl",
+		Tests:     summary.Total,
+		Failures:  summary.Failed + summary.TimedOut,
+		Errors:    summary.Interrupted,
+		Skipped:   summary.Skipped,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	var total time.Duration
+
+	for _, result := range summary.Results {
+		total += result.Duration
+
+		testCase := junitCase{
+			Name:      result.Tool.Name,
+			ClassName: "godyl." + result.Tool.Source.Type.String(),
+			Time:      seconds(result.Duration),
+			SystemOut: details(result),
+		}
+
+		outcome := &junitMessage{Message: result.Message, Type: result.Status.String()}
+		if result.Error != nil {
+			outcome.Details = result.Error.Error()
+		}
+
+		switch result.Status {
+		case processor.StatusOK:
+		case processor.StatusSkipped:
+			testCase.Skipped = outcome
+		case processor.StatusFailed, processor.StatusTimedOut:
+			testCase.Failure = outcome
+		case processor.StatusInterrupted:
+			testCase.Error = outcome
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	suite.Time = seconds(total)
+
+	report := junitSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitSuite{suite},
+	}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling report to JUnit XML: %w", err)
+	}
+
+	return xml.Header + string(out) + "\n", nil
+}
+
+// formatMarkdown renders the summary as a Markdown table, followed by the details of the errors.
+func (f *ReportFormatter) formatMarkdown(summary processor.Summary) string {
+	var sb strings.Builder
+
+	sb.WriteString("## godyl\n\n")
+	sb.WriteString(NewErrorFormatter(ErrorConfig{}).FormatSummary(summary) + "\n\n")
+
+	if len(summary.Results) == 0 {
+		return sb.String()
+	}
+
+	sb.WriteString("| Tool | Version | Status | Output | File | Message |\n")
+	sb.WriteString("| :--- | :------ | :----- | :----- | :--- | :------ |\n")
+
+	// Show the results requiring attention first, in the order of the results table.
+	order := []processor.Status{
+		processor.StatusFailed,
+		processor.StatusTimedOut,
+		processor.StatusInterrupted,
+		processor.StatusSkipped,
+		processor.StatusOK,
+	}
+
+	results := slices.Clone(summary.Results)
+	slices.SortStableFunc(results, func(a, b processor.Result) int {
+		return slices.Index(order, a.Status) - slices.Index(order, b.Status)
+	})
+
+	for _, result := range results {
+		tool := result.Tool
+
+		fileDisplay := ""
+		if tool.URL != "" {
+			fileDisplay = file.File(tool.URL).Unescape().Base()
+		}
+
+		fmt.Fprintf(&sb, "| %s | %s | %s %s | %s | %s | %s |\n",
+			cell(tool.Name),
+			cell(tool.Version.Version),
+			statusIcon(result.Status),
+			result.Status,
+			cell(tool.Output),
+			cell(fileDisplay),
+			cell(result.Message),
+		)
+	}
+
+	if len(summary.Errors) == 0 {
+		return sb.String()
+	}
+
+	errorOutput, _ := NewErrorFormatter(ErrorConfig{Format: ErrorFormatText}).FormatErrors(summary.Errors)
+
+	sb.WriteString("\n<details>\n<summary>Errors</summary>\n\n```text\n")
+	sb.WriteString(errorOutput)
+	sb.WriteString("\n```\n\n</details>\n")
+
+	return sb.String()
+}
+
+// details returns the resolved details of the tool of the result.
+func details(result processor.Result) string {
+	tool := result.Tool
+
+	var lines []string
+
+	for _, detail := range [][2]string{
+		{"version", tool.Version.Version},
+		{"url", tool.URL},
+		{"output", tool.Output},
+		{"phases", formatPhases(result.Phases, ", ")},
+	} {
+		if detail[1] != "" {
+			lines = append(lines, detail[0]+": "+detail[1])
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// seconds formats the duration in seconds, as used by JUnit XML.
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// cell escapes the value for a Markdown table cell.
+func cell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// statusIcon returns the icon representing the status in Markdown.
+func statusIcon(status processor.Status) string {
+	switch status {
+	case processor.StatusOK:
+		return "✅"
+	case processor.StatusSkipped:
+		return "⏭️"
+	case processor.StatusFailed:
+		return "❌"
+	case processor.StatusTimedOut:
+		return "⏱️"
+	case processor.StatusInterrupted:
+		return "⚠️"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,152 @@
+package presentation_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/presentation"
+	"github.com/idelchi/godyl/internal/processor"
+	"github.com/idelchi/godyl/internal/tools/tool"
+)
+
+// summary returns a summary with one result of each status.
+func summary() processor.Summary {
+	newTool := func(name string) *tool.Tool {
+		t := &tool.Tool{Name: name, Output: "/usr/local/bin"}
+		t.Version.Version = "v1.0.0"
+
+		return t
+	}
+
+	summary := processor.Summary{
+		Results: []processor.Result{
+			{Tool: newTool("ok"), Status: processor.StatusOK, Message: "installed"},
+			{Tool: newTool("skipped"), Status: processor.StatusSkipped, Message: "already installed"},
+			{Tool: newTool("failed"), Status: processor.StatusFailed, Message: "a | b", Error: errors.New("not found")},
+			{Tool: newTool("timed-out"), Status: processor.StatusTimedOut, Error: errors.New("timed out after 2s")},
+			{Tool: newTool("interrupted"), Status: processor.StatusInterrupted, Message: "interrupted"},
+		},
+		Total:       5,
+		Successful:  1,
+		Skipped:     1,
+		Failed:      1,
+		TimedOut:    1,
+		Interrupted: 1,
+	}
+
+	summary.Errors = []processor.ErrorDetail{
+		{Tool: "failed", Message: "a | b", Error: errors.New("not found")},
+	}
+
+	return summary
+}
+
+func TestReportFormatterJUnit(t *testing.T) {
+	t.Parallel()
+
+	out, err := presentation.NewReportFormatter(root.ReportJUnit).Format(summary())
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	type outcome struct {
+		Message string `xml:"message,attr"`
+		Details string `xml:",chardata"`
+	}
+
+	var report struct {
+		Tests  int `xml:"tests,attr"`
+		Suites []struct {
+			Failures int `xml:"failures,attr"`
+			Errors   int `xml:"errors,attr"`
+			Skipped  int `xml:"skipped,attr"`
+			Cases    []struct {
+				Name    string   `xml:"name,attr"`
+				Skipped *outcome `xml:"skipped"`
+				Failure *outcome `xml:"failure"`
+				Error   *outcome `xml:"error"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+
+	if err := xml.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("Format() produced invalid XML: %v\n%s", err, out)
+	}
+
+	if report.Tests != 5 || len(report.Suites) != 1 {
+		t.Fatalf("Format() = %d tests in %d suites, want 5 tests in 1 suite", report.Tests, len(report.Suites))
+	}
+
+	suite := report.Suites[0]
+	if suite.Failures != 2 || suite.Errors != 1 || suite.Skipped != 1 {
+		t.Errorf("suite counts = %d failures, %d errors, %d skipped, want 2, 1, 1",
+			suite.Failures, suite.Errors, suite.Skipped)
+	}
+
+	for _, c := range suite.Cases {
+		var got string
+
+		switch {
+		case c.Skipped != nil:
+			got = "skipped"
+		case c.Failure != nil:
+			got = "failure"
+		case c.Error != nil:
+			got = "error"
+		default:
+			got = "passed"
+		}
+
+		want := map[string]string{
+			"ok":          "passed",
+			"skipped":     "skipped",
+			"failed":      "failure",
+			"timed-out":   "failure",
+			"interrupted": "error",
+		}[c.Name]
+
+		if got != want {
+			t.Errorf("testcase %q is %s, want %s", c.Name, got, want)
+		}
+
+		if c.Name == "failed" && c.Failure.Details != "not found" {
+			t.Errorf("testcase %q details = %q, want the error", c.Name, c.Failure.Details)
+		}
+	}
+}
+
+func TestReportFormatterMarkdown(t *testing.T) {
+	t.Parallel()
+
+	out, err := presentation.NewReportFormatter(root.ReportMarkdown).Format(summary())
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"| Tool | Version | Status | Output | File | Message |",
+		"| failed | v1.0.0 | ❌ failed | /usr/local/bin |  | a \\| b |",
+		"<summary>Errors</summary>",
+		"not found",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Format() is missing %q:\n%s", want, out)
+		}
+	}
+
+	// Results requiring attention are listed first
+	if strings.Index(out, "| failed ") > strings.Index(out, "| ok ") {
+		t.Errorf("Format() lists successful tools before failed ones:\n%s", out)
+	}
+}
+
+func TestReportFormatterUnknown(t *testing.T) {
+	t.Parallel()
+
+	if _, err := presentation.NewReportFormatter("html").Format(summary()); err == nil {
+		t.Error("Format() with an unknown format succeeded, want an error")
+	}
+}
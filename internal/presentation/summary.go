@@ -38,12 +38,14 @@ func ShowSummary(summary processor.Summary, cfg ShowConfig, log *logger.Logger)
 		log.Info(tableOutput)
 
 		log.Infof("%d tools processed", len(summary.Results))
+	} else if summary.Interrupted > 0 {
+		log.Warn("Interrupted!")
 	} else {
 		log.Info("Done!")
 	}
 
 	// Handle errors
-	if summary.HasErrors() {
+	if len(summary.Errors) > 0 {
 		showErrors(summary, cfg, log)
 	}
 }
@@ -3,6 +3,8 @@ package presentation
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -37,12 +39,14 @@ func (f *TableFormatter) RenderResults(results []processor.Result) string {
 	}
 
 	// Split results by status
-	var errors, warnings, successes []processor.Result
+	var errors, interrupted, warnings, successes []processor.Result
 
 	for _, result := range results {
 		switch result.Status {
-		case processor.StatusFailed:
+		case processor.StatusFailed, processor.StatusTimedOut:
 			errors = append(errors, result)
+		case processor.StatusInterrupted:
+			interrupted = append(interrupted, result)
 		case processor.StatusSkipped:
 			warnings = append(warnings, result)
 		case processor.StatusOK:
@@ -60,6 +64,14 @@ func (f *TableFormatter) RenderResults(results []processor.Result) string {
 		output += f.renderTable(errors)
 	}
 
+	// Render interrupted tools next, as they were neither completed nor failed
+	if len(interrupted) > 0 {
+		interruptedHeader := color.MagentaString("\n=== INTERRUPTED (%d tools not completed) ===\n", len(interrupted))
+
+		output += interruptedHeader
+		output += f.renderTable(interrupted)
+	}
+
 	// Render warnings second (medium priority)
 	if len(warnings) > 0 {
 		warningHeader := color.YellowString("\n=== WARNINGS (%d skipped tools) ===\n", len(warnings))
@@ -95,9 +107,14 @@ func (f *TableFormatter) renderTable(results []processor.Result) string {
 		{Name: "OS/ARCH", WidthMax: f.config.MaxWidth},
 		{Name: "File", WidthMax: f.config.MaxWidth},
 		{Name: "Checksum", WidthMax: f.config.MaxWidth},
-		{Name: "Status", WidthMax: f.config.MaxWidth, Bold: true},
 	}
 
+	if f.config.Verbose {
+		headers = append(headers, HeaderConfig{Name: "Phases", WidthMax: f.config.MaxWidth})
+	}
+
+	headers = append(headers, HeaderConfig{Name: "Status", WidthMax: f.config.MaxWidth, Bold: true})
+
 	// Set up headers
 	headerRow := make(table.Row, 0, len(headers))
 	columnConfigs := make([]table.ColumnConfig, 0, len(headers))
@@ -176,7 +193,12 @@ func (f *TableFormatter) formatResultRow(result processor.Result) table.Row {
 
 	// Format message
 	message := result.Message
-	if result.Status == processor.StatusFailed && f.config.Verbose {
+	switch {
+	case result.Status == processor.StatusTimedOut && f.config.Verbose:
+		message = "timed out, see below for details"
+	case result.Status == processor.StatusTimedOut:
+		message = "timed out: " + message
+	case result.Status == processor.StatusFailed && f.config.Verbose:
 		message = "failed, see below for details"
 	}
 
@@ -197,15 +219,33 @@ func (f *TableFormatter) formatResultRow(result processor.Result) table.Row {
 		checksum = na
 	}
 
-	return table.Row{
+	row := table.Row{
 		exeName,
 		tool.Version.Version,
 		tool.Output,
 		fmt.Sprintf("%s/%s", tool.Platform.OS.Name, tool.Platform.Architecture.Name),
 		fileDisplay,
 		checksum,
-		message,
 	}
+
+	if f.config.Verbose {
+		row = append(row, formatPhases(result.Phases, "\n"))
+	}
+
+	return append(row, message)
+}
+
+// formatPhases formats the time spent in each phase, separated by sep.
+func formatPhases(phases []processor.PhaseDuration, sep string) string {
+	const precision = 10 * time.Millisecond
+
+	formatted := make([]string, 0, len(phases))
+
+	for _, phase := range phases {
+		formatted = append(formatted, fmt.Sprintf("%s %s", phase.Phase, phase.Duration.Round(precision)))
+	}
+
+	return strings.Join(formatted, sep)
 }
 
 // getColorForStatus returns the appropriate color for a given status.
@@ -217,6 +257,10 @@ func (f *TableFormatter) getColorForStatus(status processor.Status) text.Colors
 		return text.Colors{text.FgRed}
 	case processor.StatusSkipped:
 		return text.Colors{text.FgYellow}
+	case processor.StatusInterrupted:
+		return text.Colors{text.FgMagenta}
+	case processor.StatusTimedOut:
+		return text.Colors{text.FgHiRed}
 	default:
 		return text.Colors{text.BgBlack}
 	}
@@ -0,0 +1,117 @@
+package presentation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	"github.com/idelchi/godyl/internal/tools/verify"
+	"github.com/idelchi/godyl/pkg/logger"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+// ShowVerify displays the outcomes of the verification of the installed tools.
+func ShowVerify(reports []verify.Report, log *logger.Logger) {
+	if len(reports) == 0 {
+		log.Info("No installed tools to verify")
+
+		return
+	}
+
+	log.Info("")
+	log.Info(RenderVerify(reports))
+	log.Info(FormatVerifyCounts(reports))
+}
+
+// RenderVerify renders the outcomes of the verification as a table, the most severe first.
+func RenderVerify(reports []verify.Report) string {
+	const maxWidth = 100
+
+	reports = slices.Clone(reports)
+	slices.SortStableFunc(reports, func(a, b verify.Report) int {
+		if order := slices.Index(verify.Outcomes, a.Outcome) - slices.Index(verify.Outcomes, b.Outcome); order != 0 {
+			return order
+		}
+
+		return strings.Compare(a.Tool, b.Tool)
+	})
+
+	t := table.NewWriter()
+
+	header := table.Row{"Tool", "Version", "Asset", "Checksum", "Outcome", "Details"}
+
+	t.AppendHeader(header)
+
+	columns := make([]table.ColumnConfig, 0, len(header))
+	for i := range header {
+		columns = append(columns, table.ColumnConfig{Number: i + 1, WidthMax: maxWidth})
+	}
+
+	t.SetColumnConfigs(columns)
+	t.SetStyle(table.StyleRounded)
+
+	t.Style().Color.Header = text.Colors{text.FgBlue, text.Bold}
+
+	const maxChecksumDisplay = 8
+
+	for _, report := range reports {
+		asset := ""
+		if report.URL != "" {
+			asset = file.File(report.URL).Unescape().Base()
+		}
+
+		checksum := report.Checksum
+		if len(checksum) > maxChecksumDisplay {
+			checksum = report.Algorithm + ":" + checksum[:maxChecksumDisplay] + "..."
+		}
+
+		t.AppendRow(table.Row{
+			report.Tool,
+			report.Version,
+			asset,
+			checksum,
+			verifyColors(report.Outcome).Sprint(report.Outcome),
+			report.Message,
+		})
+	}
+
+	return t.Render()
+}
+
+// FormatVerifyCounts formats the number of tools with each outcome, the most severe first.
+func FormatVerifyCounts(reports []verify.Report) string {
+	counts := make([]string, 0, len(verify.Outcomes))
+
+	for _, outcome := range verify.Outcomes {
+		count := 0
+
+		for _, report := range reports {
+			if report.Outcome == outcome {
+				count++
+			}
+		}
+
+		if count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count, outcome))
+		}
+	}
+
+	return fmt.Sprintf("%d tools verified: %s", len(reports), strings.Join(counts, ", "))
+}
+
+// verifyColors returns the colors for an outcome.
+func verifyColors(outcome verify.Outcome) text.Colors {
+	switch outcome {
+	case verify.Fail:
+		return text.Colors{text.FgRed}
+	case verify.Unverifiable:
+		return text.Colors{text.FgYellow}
+	case verify.Pass:
+		return text.Colors{text.FgGreen}
+	default:
+		return nil
+	}
+}
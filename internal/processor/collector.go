@@ -45,6 +45,14 @@ func (c *collector) Summary() Summary {
 		case StatusFailed:
 			summary.Failed++
 
+			summary.Errors = append(summary.Errors, ErrorDetail{
+				Tool:    r.Tool.Name,
+				Message: r.Message,
+				Error:   r.Error,
+			})
+		case StatusTimedOut:
+			summary.TimedOut++
+
 			summary.Errors = append(summary.Errors, ErrorDetail{
 				Tool:    r.Tool.Name,
 				Message: r.Message,
@@ -52,6 +60,8 @@ func (c *collector) Summary() Summary {
 			})
 		case StatusSkipped:
 			summary.Skipped++
+		case StatusInterrupted:
+			summary.Interrupted++
 		}
 	}
 
@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"github.com/hashicorp/go-getter/v2"
+
+	"github.com/idelchi/godyl/internal/events"
+	"github.com/idelchi/godyl/internal/tools/tool"
+)
+
+// tracker returns the progress tracker for the download of the tool, emitting the start and end of its transfers.
+func (p *Processor) tracker(t *tool.Tool) getter.ProgressTracker {
+	if _, ok := p.events.(events.Noop); ok {
+		return p.progress.Tracker()
+	}
+
+	return events.Track(p.progress.Tracker(), p.events, t.Name, t.URL)
+}
+
+// emitResolved emits the version and asset resolved for the tool.
+func (p *Processor) emitResolved(t *tool.Tool) {
+	if t.Version.Version != "" {
+		p.events.Emit(events.Event{Type: events.VersionResolved, Tool: t.Name, Version: t.Version.Version})
+	}
+
+	if t.URL != "" {
+		p.events.Emit(events.Event{Type: events.AssetSelected, Tool: t.Name, URL: t.URL, Checksum: checksum(t)})
+	}
+}
+
+// emitInstalled emits the verification of the checksum and the installation of the tool.
+func (p *Processor) emitInstalled(t *tool.Tool) {
+	if sum := checksum(t); sum != "" {
+		p.events.Emit(events.Event{Type: events.ChecksumVerified, Tool: t.Name, Checksum: sum})
+	}
+
+	p.events.Emit(events.Event{Type: events.Installed, Tool: t.Name, Version: t.Version.Version, Output: t.Output})
+}
+
+// emitResult emits the outcome of a tool that was not installed.
+// Installed tools are reported by emitInstalled, and resolved ones by the resolved event.
+func (p *Processor) emitResult(result Result) {
+	name := result.Tool.Name
+
+	switch result.Status {
+	case StatusOK:
+		return
+	case StatusSkipped:
+		p.events.Emit(events.Event{Type: events.Skipped, Tool: name, Message: result.Message})
+	case StatusFailed, StatusTimedOut, StatusInterrupted:
+		event := events.Event{Type: events.Failed, Tool: name, Status: result.Status.String(), Message: result.Message}
+
+		if result.Error != nil {
+			event.Error = result.Error.Error()
+		}
+
+		p.events.Emit(event)
+	}
+}
+
+// emitSummary emits the summary of the run.
+func (p *Processor) emitSummary(summary Summary) {
+	run := &events.RunSummary{
+		Errors:      make([]events.ToolError, 0, len(summary.Errors)),
+		Total:       summary.Total,
+		Successful:  summary.Successful,
+		Failed:      summary.Failed,
+		TimedOut:    summary.TimedOut,
+		Skipped:     summary.Skipped,
+		Interrupted: summary.Interrupted,
+	}
+
+	for _, e := range summary.Errors {
+		detail := events.ToolError{Tool: e.Tool, Message: e.Message}
+
+		if e.Error != nil {
+			detail.Error = e.Error.Error()
+		}
+
+		run.Errors = append(run.Errors, detail)
+	}
+
+	p.events.Emit(events.Event{Type: events.Summary, Summary: run})
+}
+
+// checksum returns the checksum the download of the tool is verified against, if any.
+func checksum(t *tool.Tool) string {
+	if !t.Checksum.IsMandatory() || t.NoVerifyChecksum {
+		return ""
+	}
+
+	return t.Checksum.Type.String() + ":" + t.Checksum.Value
+}
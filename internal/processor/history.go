@@ -0,0 +1,50 @@
+package processor
+
+import (
+	"github.com/idelchi/godyl/internal/history"
+	"github.com/idelchi/godyl/internal/tools/tool"
+)
+
+// change returns the change the download of the tool makes, from the version currently installed.
+func (p *Processor) change(t *tool.Tool) history.Entry {
+	if p.history == nil {
+		return history.Entry{}
+	}
+
+	entry := history.Entry{
+		Tool:   t.Name,
+		Action: history.Install,
+		Path:   t.AbsPath(),
+		Source: t.Source.Type.String(),
+		Godyl:  p.Version,
+	}
+
+	if t.Exists() {
+		entry.Action = history.Update
+		entry.From = t.GetCurrentVersion()
+	}
+
+	return entry
+}
+
+// record appends the change with the outcome of the download of the tool to the history.
+func (p *Processor) record(change history.Entry, result Result) {
+	if p.history == nil {
+		return
+	}
+
+	t := result.Tool
+
+	change.To = t.Version.Version
+	change.URL = t.URL
+	change.Checksum = checksum(t)
+	change.Outcome = result.Status.String()
+
+	if result.Error != nil {
+		change.Error = result.Error.Error()
+	}
+
+	if err := p.history.Append(change); err != nil {
+		p.log.Warnf("recording history of %q: %v", t.Name, err)
+	}
+}
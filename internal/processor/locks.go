@@ -0,0 +1,121 @@
+package processor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/idelchi/godyl/pkg/flock"
+	"github.com/idelchi/godyl/pkg/logger"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+// outputLocks keeps other processes from installing into the output directories this process installs into.
+// The tools of this process share the lock of an output directory, which is released once the last of them is done.
+type outputLocks struct {
+	dir  folder.Folder
+	log  *logger.Logger
+	mu   sync.Mutex
+	held map[string]*outputLock
+}
+
+// outputLock is the lock of an output directory, with the number of tools using it.
+type outputLock struct {
+	lock   *flock.Lock
+	mu     sync.Mutex
+	locked bool
+	users  int
+}
+
+// newOutputLocks creates the locks of the output directories, with the lock files stored in dir.
+func newOutputLocks(dir folder.Folder, log *logger.Logger) *outputLocks {
+	return &outputLocks{
+		dir:  dir,
+		log:  log,
+		held: make(map[string]*outputLock),
+	}
+}
+
+// acquire locks the output directory, waiting for other processes to release it.
+// The returned function releases the lock again.
+func (l *outputLocks) acquire(ctx context.Context, output string) (func(), error) {
+	output = folder.New(output).Absolute().Path()
+
+	l.mu.Lock()
+
+	entry, ok := l.held[output]
+	if !ok {
+		sum := sha256.Sum256([]byte(output))
+
+		entry = &outputLock{lock: flock.New(l.dir.WithFile(hex.EncodeToString(sum[:8]) + ".lock"))}
+		l.held[output] = entry
+	}
+
+	entry.users++
+
+	l.mu.Unlock()
+
+	release := func() { l.release(output, entry) }
+
+	if err := entry.acquire(ctx, l.log, output); err != nil {
+		release()
+
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// acquire takes the lock, unless another tool of this process already did.
+func (e *outputLock) acquire(ctx context.Context, log *logger.Logger, output string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.locked {
+		return nil
+	}
+
+	err := e.lock.TryLock()
+	if errors.Is(err, flock.ErrLocked) {
+		log.Infof("waiting for another process installing into %q", output)
+
+		err = e.lock.Lock(ctx)
+	}
+
+	if err != nil {
+		return err //nolint:wrapcheck	// Error does not need additional wrapping.
+	}
+
+	e.locked = true
+
+	return nil
+}
+
+// release releases the output directory once no tool uses it anymore.
+func (l *outputLocks) release(output string, entry *outputLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry.users--
+
+	if entry.users > 0 {
+		return
+	}
+
+	delete(l.held, output)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if !entry.locked {
+		return
+	}
+
+	entry.locked = false
+
+	if err := entry.lock.Unlock(); err != nil {
+		l.log.Debugf("releasing lock of %q: %v", output, err)
+	}
+}
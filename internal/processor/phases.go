@@ -0,0 +1,79 @@
+package processor
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/idelchi/godyl/pkg/download/progress"
+)
+
+// PhaseDuration is the time spent in a phase of processing a tool.
+type PhaseDuration struct {
+	Phase    progress.Phase
+	Duration time.Duration
+}
+
+// phases records the time spent in each phase of processing a tool, displaying the current one.
+type phases struct {
+	since     time.Time
+	row       *progress.PhaseRow
+	current   progress.Phase
+	durations []PhaseDuration
+	mu        sync.Mutex
+}
+
+// newPhases returns a recorder of the phases of a tool, displayed in the row.
+func newPhases(row *progress.PhaseRow) *phases {
+	return &phases{row: row}
+}
+
+// enter ends the current phase and starts the given one.
+// Entering the current phase again continues it.
+func (p *phases) enter(phase progress.Phase) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if phase == p.current {
+		return
+	}
+
+	p.end()
+
+	p.current = phase
+	p.since = time.Now()
+
+	p.row.Enter(phase)
+}
+
+// done ends the current phase, removes the row and returns the time spent in each phase, in the order first entered.
+func (p *phases) done() []PhaseDuration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.end()
+
+	p.current = ""
+
+	p.row.Done()
+
+	return p.durations
+}
+
+// end adds the time spent in the current phase, if any.
+func (p *phases) end() {
+	if p.current == "" {
+		return
+	}
+
+	elapsed := time.Since(p.since)
+
+	i := slices.IndexFunc(p.durations, func(d PhaseDuration) bool { return d.Phase == p.current })
+	if i < 0 {
+		p.durations = append(p.durations, PhaseDuration{Phase: p.current, Duration: elapsed})
+
+		return
+	}
+
+	p.durations[i].Duration += elapsed
+}
@@ -0,0 +1,59 @@
+package processor
+
+import (
+	"context"
+
+	"github.com/idelchi/godyl/internal/github"
+	"github.com/idelchi/godyl/internal/tools/tool"
+)
+
+// prefetch looks up the latest GitHub releases of the tools ahead of their resolution,
+// batching the repositories into a few GraphQL requests per token instead of one REST request per tool.
+// Tools whose release isn't found this way resolve it with the REST API as usual.
+func (p *Processor) prefetch(ctx context.Context) {
+	type batch struct {
+		names []github.Name
+		tools map[github.Name][]*tool.Tool
+	}
+
+	batches := make(map[string]*batch)
+
+	var tokens []string
+
+	for _, t := range p.tools {
+		name, token, ok := t.GitHubRepository()
+		if !ok {
+			continue
+		}
+
+		b, found := batches[token]
+		if !found {
+			b = &batch{tools: make(map[github.Name][]*tool.Tool)}
+			batches[token] = b
+			tokens = append(tokens, token)
+		}
+
+		if _, seen := b.tools[name]; !seen {
+			b.names = append(b.names, name)
+		}
+
+		b.tools[name] = append(b.tools[name], t)
+	}
+
+	for _, token := range tokens {
+		b := batches[token]
+
+		releases, err := github.LatestReleases(ctx, github.NewClient(token), b.names)
+		if err != nil {
+			p.log.Debugf("looking up latest releases with GraphQL: %v", err)
+		}
+
+		p.log.Debugf("looked up %d of %d latest releases with GraphQL", len(releases), len(b.names))
+
+		for name, release := range releases {
+			for _, t := range b.tools[name] {
+				t.UseLatestRelease(release)
+			}
+		}
+	}
+}
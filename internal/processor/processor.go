@@ -3,7 +3,9 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -11,11 +13,18 @@ import (
 	"github.com/idelchi/godyl/internal/cache"
 	"github.com/idelchi/godyl/internal/config/root"
 	"github.com/idelchi/godyl/internal/data"
+	"github.com/idelchi/godyl/internal/events"
+	"github.com/idelchi/godyl/internal/history"
 	"github.com/idelchi/godyl/internal/tools"
 	"github.com/idelchi/godyl/internal/tools/result"
+	"github.com/idelchi/godyl/internal/tools/sources/install"
 	"github.com/idelchi/godyl/internal/tools/tags"
 	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/pkg/download"
+	"github.com/idelchi/godyl/pkg/download/progress"
 	"github.com/idelchi/godyl/pkg/logger"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
 	"github.com/idelchi/godyl/pkg/pretty"
 )
 
@@ -23,21 +32,49 @@ import (
 type Processor struct {
 	results    *collector
 	cache      *cache.Cache
+	archives   *download.ArchiveCache
+	partials   folder.Folder
+	shared     *install.Shared
 	progress   *progressMgr
+	events     events.Emitter
 	config     root.Config
 	log        *logger.Logger
 	tools      tools.Tools
+	history    *history.History
+	locks      *outputLocks
 	Options    []tool.ResolveOption
+	Version    string
 	NoDownload bool
 }
 
 // New creates a new Processor.
 func New(toolsList tools.Tools, cfg root.Config, log *logger.Logger) *Processor {
-	// Initialize cache
-	var cacheManager *cache.Cache
-
+	// Initialize cache, with the history of the changes to the tools next to it
+	var (
+		cacheManager *cache.Cache
+		changes      *history.History
+		partials     folder.Folder
+	)
+
+	// Keep the partial downloads in the cache as well, to resume them later
 	if !cfg.Cache.Disabled {
 		cacheManager = cache.New(data.CacheFile(cfg.Cache.Dir))
+		changes = history.New(data.HistoryFile(cfg.Cache.Dir))
+		partials = data.PartialsDir(cfg.Cache.Dir)
+	}
+
+	// Initialize archive cache, the size limit has been validated with the configuration
+	var archives *download.ArchiveCache
+
+	if limit, err := cfg.Cache.ArchivesLimit(); cfg.Cache.Archives && err == nil {
+		archives = download.NewArchiveCache(data.ArchivesDir(cfg.Cache.Dir), limit)
+	}
+
+	// Stream the events as NDJSON, without progress bars interfering with them
+	var emitter events.Emitter = events.Noop{}
+
+	if cfg.OutputFormat == root.OutputNDJSON {
+		emitter = events.NewWriter(os.Stdout)
 	}
 
 	return &Processor{
@@ -46,13 +83,19 @@ func New(toolsList tools.Tools, cfg root.Config, log *logger.Logger) *Processor
 		log:      log,
 		results:  newCollector(),
 		cache:    cacheManager,
-		progress: newProgressMgr(cfg.NoProgress),
+		history:  changes,
+		locks:    newOutputLocks(data.LocksDir(cfg.Cache.Dir), log),
+		archives: archives,
+		partials: partials,
+		shared:   install.NewShared(),
+		progress: newProgressMgr(cfg.NoProgress || cfg.OutputFormat == root.OutputNDJSON),
+		events:   emitter,
 	}
 }
 
 // Process installs and manages tools with the given tags.
 // Returns the aggregated summary and any infrastructure error (e.g. cache load failure).
-func (p *Processor) Process(tags tags.IncludeTags) (Summary, error) {
+func (p *Processor) Process(ctx context.Context, tags tags.IncludeTags) (Summary, error) {
 	// 1. Setup
 	if p.cache != nil {
 		if err := p.cache.Load(); err != nil {
@@ -60,13 +103,11 @@ func (p *Processor) Process(tags tags.IncludeTags) (Summary, error) {
 		}
 	}
 
-	// 2. Process tools concurrently
-	ctx := context.Background()
-	g, ctx := errgroup.WithContext(ctx)
+	// 2. Look up the latest GitHub releases in batches
+	p.prefetch(ctx)
 
-	if p.config.Tokens.GitHub == "" {
-		p.config.Parallel = 1
-	}
+	// 3. Process tools concurrently
+	g, ctx := errgroup.WithContext(ctx)
 
 	if p.config.Parallel > 0 {
 		g.SetLimit(p.config.Parallel)
@@ -77,17 +118,28 @@ func (p *Processor) Process(tags tags.IncludeTags) (Summary, error) {
 	// Start progress tracking
 	p.progress.Start()
 
+	for _, t := range p.tools {
+		p.events.Emit(events.Event{Type: events.Queued, Tool: t.Name})
+	}
+
 	for _, t := range p.tools {
 		// capture
 		g.Go(func() error {
 			// Run the tool operation
-			result := p.runTool(ctx, t, tags)
+			phases := newPhases(p.progress.PhaseRow(t.Name))
+
+			start := time.Now()
+			result := p.runTool(progress.WithPhaseListener(ctx, phases.enter), t, tags)
+			result.Duration = time.Since(start)
+			result.Phases = phases.done()
+
+			p.emitResult(result)
 
 			// Collect the result
 			p.results.Add(result)
 
-			// Update cache if successful
-			if result.Status == StatusOK && p.cache != nil {
+			// Update cache if installed successfully
+			if result.Status == StatusOK && p.cache != nil && !p.NoDownload && p.Options == nil {
 				p.updateCache(result) //nolint:contextcheck	// Unclear what this is about.
 			}
 
@@ -95,23 +147,43 @@ func (p *Processor) Process(tags tags.IncludeTags) (Summary, error) {
 		})
 	}
 
-	// 3. Wait for completion
-	if err := g.Wait(); err != nil {
+	// 4. Wait for completion
+	err := g.Wait()
+
+	// The shared downloads are only needed while the tools are installed
+	if err := p.shared.Remove(); err != nil {
+		p.log.Debugf("removing shared downloads: %v", err)
+	}
+
+	if err != nil {
 		return Summary{}, fmt.Errorf("processing tools: %w", err)
 	}
 
 	p.progress.Wait()
 
-	return p.results.Summary(), nil
+	summary := p.results.Summary()
+
+	p.emitSummary(summary)
+
+	return summary, nil
 }
 
 // runTool executes a tool operation and returns the result.
 func (p *Processor) runTool(ctx context.Context, t *tool.Tool, tags tags.IncludeTags) Result {
+	// Don't start any new work once interrupted
+	if ctx.Err() != nil {
+		return p.interrupted(t, ctx.Err())
+	}
+
 	// Enable cache if available
 	if p.cache != nil {
 		t.EnableCache(p.cache)
 	}
 
+	t.EnableArchiveCache(p.archives)
+	t.EnableResume(p.partials)
+	t.EnableSharedDownloads(p.shared)
+
 	// Log tool configuration
 	p.log.Debug("Tool:")
 	p.log.Debug("-------")
@@ -119,24 +191,79 @@ func (p *Processor) runTool(ctx context.Context, t *tool.Tool, tags tags.Include
 	p.log.Debug("-------")
 
 	// Resolve the tool
-	resolveResult := t.Resolve(tags, p.Options...)
+	resolveResult := t.Resolve(ctx, tags, p.Options...)
 
 	// Convert internal result to Result
+	if ctx.Err() != nil {
+		return p.interrupted(t, ctx.Err())
+	}
+
 	if !resolveResult.IsOK() {
 		return p.convertResult(t, resolveResult)
 	}
 
+	p.emitResolved(t)
+
 	// Check if we should skip download
 	if p.NoDownload || p.Options != nil {
 		t.DisableCache()
 
+		p.events.Emit(events.Event{Type: events.Resolved, Tool: t.Name, Message: resolveResult.Message})
+
 		return p.convertResult(t, resolveResult)
 	}
 
+	// Keep other processes from installing into the same output directory meanwhile
+	release, err := p.locks.acquire(ctx, t.Output)
+	if err != nil {
+		if ctx.Err() != nil {
+			return p.interrupted(t, ctx.Err())
+		}
+
+		return p.convertResult(t, result.WithFailed("locking output directory").Wrap(err))
+	}
+	defer release()
+
+	// Remember the installed version, to record the change in the history
+	change := p.change(t)
+
 	// Download the tool
-	downloadResult := t.Download(ctx, p.progress.Tracker())
+	downloadResult := t.Download(ctx, p.tracker(t))
+
+	if !downloadResult.IsOK() && ctx.Err() != nil {
+		result := p.interrupted(t, ctx.Err())
+
+		p.record(change, result)
+
+		return result
+	}
+
+	if downloadResult.IsOK() {
+		p.emitInstalled(t)
+	}
 
-	return p.convertResult(t, downloadResult)
+	result := p.convertResult(t, downloadResult)
+
+	p.record(change, result)
+
+	return result
+}
+
+// interrupted returns the result for a tool whose operation was cancelled.
+func (p *Processor) interrupted(t *tool.Tool, err error) Result {
+	t.DisableCache()
+
+	return Result{
+		Tool:    t,
+		Status:  StatusInterrupted,
+		Message: "interrupted",
+		Error:   err,
+		Metadata: map[string]any{
+			"url":     t.URL,
+			"version": t.Version.Version,
+			"output":  t.Output,
+		},
+	}
 }
 
 // convertResult converts an internal result.Result to a processor Result.
@@ -148,6 +275,8 @@ func (p *Processor) convertResult(t *tool.Tool, res result.Result) Result {
 		status = StatusOK
 	case res.IsSkipped():
 		status = StatusSkipped
+	case res.IsFailed() && errors.Is(res.AsError(), tool.ErrTimeout):
+		status = StatusTimedOut
 	case res.IsFailed():
 		status = StatusFailed
 	}
@@ -171,10 +300,6 @@ func (p *Processor) updateCache(result Result) {
 		result.Tool.Version.Version = result.Tool.GetCurrentVersion()
 	}
 
-	if result.Tool.Version.Version == "" {
-		return // No version information available
-	}
-
 	now := time.Now()
 
 	item := &cache.Item{
@@ -189,6 +314,17 @@ func (p *Processor) updateCache(result Result) {
 		Updated:    now,
 	}
 
+	// Remember the installed file, to detect changes made to it later on
+	if installed := file.New(item.Path); installed.IsFile() {
+		if hash, err := installed.Hash(); err == nil {
+			item.Hash = hash
+		}
+
+		if info, err := installed.Info(); err == nil {
+			item.Size = info.Size()
+		}
+	}
+
 	if err := p.cache.Add(item); err != nil {
 		p.log.Errorf("failed to update cache for %s: %v", result.Tool.Name, err)
 	}
@@ -41,3 +41,8 @@ func (m *progressMgr) Wait() {
 func (m *progressMgr) Tracker() getter.ProgressTracker {
 	return m.trackable
 }
+
+// PhaseRow returns the row displaying the phases of the named tool.
+func (m *progressMgr) PhaseRow(name string) *progress.PhaseRow {
+	return m.trackable.PhaseRow(name)
+}
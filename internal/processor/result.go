@@ -3,6 +3,7 @@ package processor
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/idelchi/godyl/internal/tools/tool"
 )
@@ -13,7 +14,9 @@ type Result struct {
 	Tool     *tool.Tool
 	Metadata map[string]any
 	Message  string
+	Phases   []PhaseDuration
 	Status   Status
+	Duration time.Duration
 }
 
 // Status represents the possible states of a tool operation.
@@ -26,16 +29,40 @@ const (
 	StatusSkipped
 	// StatusFailed indicates the operation failed.
 	StatusFailed
+	// StatusInterrupted indicates the operation was cancelled before completing.
+	StatusInterrupted
+	// StatusTimedOut indicates the operation failed by exceeding the timeout of the tool.
+	StatusTimedOut
 )
 
+// String returns the name of the status.
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusSkipped:
+		return "skipped"
+	case StatusFailed:
+		return "failed"
+	case StatusInterrupted:
+		return "interrupted"
+	case StatusTimedOut:
+		return "timed_out"
+	default:
+		return "unknown"
+	}
+}
+
 // Summary provides an aggregated view of all results.
 type Summary struct {
-	Results    []Result
-	Errors     []ErrorDetail
-	Total      int
-	Successful int
-	Failed     int
-	Skipped    int
+	Results     []Result
+	Errors      []ErrorDetail
+	Total       int
+	Successful  int
+	Failed      int
+	Skipped     int
+	Interrupted int
+	TimedOut    int
 }
 
 // ErrorDetail contains detailed error information for a failed tool.
@@ -45,9 +72,9 @@ type ErrorDetail struct {
 	Message string
 }
 
-// HasErrors returns true if there are any failed results.
+// HasErrors returns true if there are any failed, timed out or interrupted results.
 func (s Summary) HasErrors() bool {
-	return s.Failed > 0
+	return s.Failed > 0 || s.TimedOut > 0 || s.Interrupted > 0
 }
 
 // Error returns an aggregated error if there are any failures.
@@ -56,11 +83,17 @@ func (s Summary) Error() error {
 		return nil
 	}
 
-	if s.Failed == 1 {
+	failed := s.Failed + s.TimedOut
+
+	if failed == 0 {
+		return fmt.Errorf("interrupted, %d tool(s) not completed", s.Interrupted)
+	}
+
+	if failed == 1 {
 		return errors.New("1 tool failed to install")
 	}
 
-	return fmt.Errorf("%d tools failed to install", s.Failed)
+	return fmt.Errorf("%d tools failed to install", failed)
 }
 
 // DetailedError returns a detailed error with all failure messages.
@@ -0,0 +1,218 @@
+package tokenstore
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/idelchi/godyl/internal/tokenstore/file"
+	"github.com/idelchi/godyl/internal/tokenstore/keyring"
+)
+
+// Backend is the storage of the tokens.
+type Backend interface {
+	// Available reports whether the storage can be used.
+	Available() (bool, error)
+	// Get returns the token for the key, or ErrNotFound.
+	Get(key string) (string, error)
+	// Set stores the tokens, replacing existing ones for the same keys.
+	Set(tokens map[string]string) error
+	// Delete removes the tokens for the keys, returning ErrNotFound for missing ones.
+	Delete(keys ...string) error
+	// DeleteAll removes all tokens.
+	DeleteAll() error
+	// String describes the storage, for use in messages.
+	String() string
+}
+
+// keyringBackend stores the tokens in the system keyring.
+type keyringBackend struct {
+	service string
+}
+
+func (k keyringBackend) Available() (bool, error) {
+	// Try a quick get on a non-existent key
+	_, err := keyring.Get(k.service, "__health_check__", 1*time.Second)
+
+	return !errors.Is(err, keyring.ErrTimeout), err
+}
+
+func (k keyringBackend) Get(key string) (string, error) {
+	token, err := keyring.Get(k.service, key, defaultTimeout)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+
+	return token, err
+}
+
+func (k keyringBackend) Set(tokens map[string]string) error {
+	for key, token := range tokens {
+		if err := keyring.Set(k.service, key, token, defaultTimeout); err != nil {
+			return fmt.Errorf("%s: failed to set key: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (k keyringBackend) Delete(keys ...string) error {
+	var errs []error
+
+	for _, key := range keys {
+		err := keyring.Delete(k.service, key, defaultTimeout)
+		if errors.Is(err, keyring.ErrNotFound) {
+			err = ErrNotFound
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (k keyringBackend) DeleteAll() error {
+	return keyring.DeleteAll(k.service, defaultTimeout)
+}
+
+func (k keyringBackend) String() string {
+	return "keyring"
+}
+
+// fileBackend stores the tokens in a passphrase encrypted file.
+// The decrypted contents are kept in memory, as decrypting is deliberately slow.
+type fileBackend struct {
+	path       string
+	passphrase func() (string, error)
+
+	mu     sync.Mutex
+	key    string
+	tokens map[string]string
+}
+
+// load returns the decrypted tokens, reading the file on first use.
+func (f *fileBackend) load() (map[string]string, error) {
+	if f.tokens != nil {
+		return f.tokens, nil
+	}
+
+	key, err := f.passphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := file.Read(f.path, key)
+	if err != nil {
+		return nil, err
+	}
+
+	f.key, f.tokens = key, tokens
+
+	return tokens, nil
+}
+
+// save encrypts and writes the tokens to the file.
+func (f *fileBackend) save(tokens map[string]string) error {
+	if err := file.Write(f.path, f.key, tokens); err != nil {
+		return err
+	}
+
+	f.tokens = tokens
+
+	return nil
+}
+
+func (f *fileBackend) Available() (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err := f.load()
+
+	return err == nil, err
+}
+
+func (f *fileBackend) Get(key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.load()
+	if err != nil {
+		return "", err
+	}
+
+	token, ok := tokens[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return token, nil
+}
+
+func (f *fileBackend) Set(tokens map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	updated := maps.Clone(stored)
+	maps.Copy(updated, tokens)
+
+	return f.save(updated)
+}
+
+func (f *fileBackend) Delete(keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	updated := maps.Clone(stored)
+
+	var errs []error
+
+	for _, key := range keys {
+		if _, ok := updated[key]; !ok {
+			errs = append(errs, fmt.Errorf("%s: %w", key, ErrNotFound))
+
+			continue
+		}
+
+		delete(updated, key)
+	}
+
+	if len(updated) != len(stored) {
+		if err := f.save(updated); err != nil {
+			return err
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (f *fileBackend) DeleteAll() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// No passphrase is needed to remove the file
+	if err := file.Write(f.path, "", nil); err != nil {
+		return err
+	}
+
+	f.tokens = nil
+
+	return nil
+}
+
+func (f *fileBackend) String() string {
+	return fmt.Sprintf("token file %q", f.path)
+}
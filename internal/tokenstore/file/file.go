@@ -0,0 +1,101 @@
+// Package file stores secrets in a file encrypted with a passphrase, using age with an scrypt recipient.
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+
+	pfile "github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+// Read decrypts the file at path with the passphrase and returns the secrets it contains.
+// Returns no secrets if the file does not exist.
+func Read(path, passphrase string) (map[string]string, error) {
+	data, err := pfile.New(path).Read()
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return map[string]string{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("reading token file: %w", err)
+	}
+
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("creating identity: %w", err)
+	}
+
+	reader, err := age.Decrypt(bytes.NewReader(data), identity)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting token file %q: %w", path, err)
+	}
+
+	plain, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting token file %q: %w", path, err)
+	}
+
+	secrets := map[string]string{}
+
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, fmt.Errorf("decoding token file %q: %w", path, err)
+	}
+
+	return secrets, nil
+}
+
+// Write encrypts the secrets with the passphrase and atomically replaces the file at path.
+// The file is removed if there are no secrets.
+func Write(path, passphrase string, secrets map[string]string) error {
+	file := pfile.New(path)
+
+	if len(secrets) == 0 {
+		if err := file.Remove(); err != nil {
+			return fmt.Errorf("removing token file: %w", err)
+		}
+
+		return nil
+	}
+
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("encoding secrets: %w", err)
+	}
+
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return fmt.Errorf("creating recipient: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	writer, err := age.Encrypt(&buf, recipient)
+	if err != nil {
+		return fmt.Errorf("encrypting secrets: %w", err)
+	}
+
+	if _, err := writer.Write(plain); err != nil {
+		return fmt.Errorf("encrypting secrets: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("encrypting secrets: %w", err)
+	}
+
+	if err := folder.FromFile(file).Create(0o700); err != nil {
+		return fmt.Errorf("creating token file directory: %w", err)
+	}
+
+	if err := file.WriteAtomic(buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("writing token file: %w", err)
+	}
+
+	return nil
+}
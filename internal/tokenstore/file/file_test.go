@@ -0,0 +1,81 @@
+package file_test
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/tokenstore/file"
+)
+
+func TestReadWrite(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tokens.age")
+
+	secrets, err := file.Read(path, "passphrase")
+	if err != nil || len(secrets) != 0 {
+		t.Fatalf("Read() of missing file = %v, %v, want no secrets", secrets, err)
+	}
+
+	want := map[string]string{"github-token": "secret"}
+
+	if err := file.Write(path, "passphrase", want); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got, err := file.Read(path, "passphrase")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if !maps.Equal(got, want) {
+		t.Errorf("Read() = %v, want %v", got, want)
+	}
+
+	if _, err := file.Read(path, "wrong"); err == nil {
+		t.Error("Read() with wrong passphrase succeeded")
+	}
+
+	if err := file.Write(path, "passphrase", nil); err != nil {
+		t.Fatalf("Write() without secrets error = %v", err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Write() without secrets did not remove the file: %v", err)
+	}
+}
+
+func TestWritePermissions(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not enforced on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "nested", "tokens.age")
+
+	if err := file.Write(path, "passphrase", map[string]string{"github-token": "secret"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("token file permissions = %o, want %o", perm, 0o600)
+	}
+
+	info, err = os.Stat(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0o700 {
+		t.Errorf("token file directory permissions = %o, want %o", perm, 0o700)
+	}
+}
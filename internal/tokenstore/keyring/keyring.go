@@ -75,6 +75,10 @@ func Delete(service, user string, timeout time.Duration) error {
 
 	select {
 	case err := <-ch:
+		if errors.Is(err, keyring.ErrNotFound) {
+			return ErrNotFound
+		}
+
 		return err
 	case <-time.After(timeout):
 		return fmt.Errorf("deleting secret: %w", ErrTimeout)
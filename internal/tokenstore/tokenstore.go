@@ -2,39 +2,58 @@
 package tokenstore
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
-	"github.com/idelchi/godyl/internal/tokenstore/keyring"
+	"github.com/idelchi/godyl/pkg/credentials"
 )
 
 // service is the name of the keyring service used to store tokens.
 const service = "godyl"
 
+// hostsKey is the key under which the per-host tokens are stored, as a single JSON encoded secret.
+const hostsKey = "host-tokens"
+
 const defaultTimeout = 3 * time.Second
 
-// TokenStore provides methods to manage authentication tokens using the keyring package.
+// ErrNotFound indicates that a token was not found in the store.
+var ErrNotFound = errors.New("token not found")
+
+// TokenStore provides methods to manage authentication tokens in a backend.
 type TokenStore struct {
-	Service string
+	Backend Backend
 }
 
-// New creates a new TokenStore instance with the default service name.
+// New creates a new TokenStore instance using the system keyring with the default service name.
 func New() TokenStore {
 	return TokenStore{
-		Service: service,
+		Backend: keyringBackend{service: service},
 	}
 }
 
-// Available checks if the keyring service is available for storing tokens.
-func (ts TokenStore) Available() (bool, error) {
-	// Try a quick get on a non-existent key
-	_, err := keyring.Get(ts.Service, "__health_check__", 1*time.Second)
+// NewFile creates a new TokenStore instance using a file encrypted with a passphrase.
+// The passphrase is only requested once the file is first accessed.
+func NewFile(path string, passphrase func() (string, error)) TokenStore {
+	return TokenStore{
+		Backend: &fileBackend{path: path, passphrase: passphrase},
+	}
+}
 
-	return !errors.Is(err, keyring.ErrTimeout), err
+// String describes the backend of the store.
+func (ts TokenStore) String() string {
+	return ts.Backend.String()
 }
 
-// GetAll retrieves a map of tokens from the keyring for the specified keys.
+// Available checks if the backend is available for storing tokens.
+func (ts TokenStore) Available() (bool, error) {
+	return ts.Backend.Available()
+}
+
+// GetAll retrieves a map of tokens from the store for the specified keys.
 // If a key is not found, it is skipped.
 func (ts TokenStore) GetAll(keys ...string) (map[string]string, error) {
 	tokens := make(map[string]string)
@@ -43,7 +62,7 @@ func (ts TokenStore) GetAll(keys ...string) (map[string]string, error) {
 		value, err := ts.Get(key)
 
 		switch {
-		case errors.Is(err, keyring.ErrNotFound):
+		case errors.Is(err, ErrNotFound):
 			continue
 		case err != nil:
 			return tokens, err
@@ -55,30 +74,30 @@ func (ts TokenStore) GetAll(keys ...string) (map[string]string, error) {
 	return tokens, nil
 }
 
-// SetAll sets multiple tokens in the keyring.
+// SetAll sets multiple tokens in the store.
 func (ts TokenStore) SetAll(tokens map[string]any) error {
+	values := make(map[string]string, len(tokens))
+
 	for key, token := range tokens {
 		value, ok := token.(string)
 		if !ok {
 			return fmt.Errorf("%s: invalid token type %T, expected string", key, token)
 		}
 
-		if err := ts.Set(key, value); err != nil {
-			return fmt.Errorf("%s: failed to set key: %w", key, err)
-		}
+		values[key] = value
 	}
 
-	return nil
+	return ts.Backend.Set(values)
 }
 
-// Set stores a token in the keyring for a specific key.
+// Set stores a token in the store for a specific key.
 func (ts TokenStore) Set(key, token string) error {
-	return keyring.Set(ts.Service, key, token, defaultTimeout)
+	return ts.Backend.Set(map[string]string{key: token})
 }
 
-// Get retrieves a token from the keyring for a specific key.
+// Get retrieves a token from the store for a specific key.
 func (ts TokenStore) Get(key string) (string, error) {
-	token, err := keyring.Get(ts.Service, key, defaultTimeout)
+	token, err := ts.Backend.Get(key)
 	if err != nil {
 		return "", err
 	}
@@ -86,20 +105,78 @@ func (ts TokenStore) Get(key string) (string, error) {
 	return token, nil
 }
 
-// Delete removes the given keys from the keyring.
-// If no keys are provided, it clears all keys for the service.
+// Delete removes the given keys from the store.
+// If no keys are provided, it clears all keys.
 func (ts TokenStore) Delete(keys ...string) error {
 	if len(keys) == 0 {
-		return keyring.DeleteAll(ts.Service, defaultTimeout)
+		return ts.Backend.DeleteAll()
 	}
 
-	var errs []error
+	return ts.Backend.Delete(keys...)
+}
 
-	for _, key := range keys {
-		if err := keyring.Delete(ts.Service, key, defaultTimeout); err != nil {
-			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+// MoveTo moves the tokens for the keys, as well as the per-host tokens, to the other store.
+// Returns the keys that were moved.
+func (ts TokenStore) MoveTo(other TokenStore, keys ...string) ([]string, error) {
+	tokens, err := ts.GetAll(append(slices.Clone(keys), hostsKey)...)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving tokens from %s: %w", ts, err)
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	if err := other.Backend.Set(tokens); err != nil {
+		return nil, fmt.Errorf("storing tokens in %s: %w", other, err)
+	}
+
+	moved := slices.Sorted(maps.Keys(tokens))
+
+	if err := ts.Delete(moved...); err != nil {
+		return moved, fmt.Errorf("removing tokens from %s: %w", ts, err)
+	}
+
+	return moved, nil
+}
+
+// GetHosts retrieves the per-host tokens from the store.
+// Returns no tokens if none are stored.
+func (ts TokenStore) GetHosts() (credentials.Tokens, error) {
+	value, err := ts.Get(hostsKey)
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var tokens credentials.Tokens
+
+	if err := json.Unmarshal([]byte(value), &tokens); err != nil {
+		return nil, fmt.Errorf("%s: decoding tokens: %w", hostsKey, err)
+	}
+
+	return tokens, nil
+}
+
+// SetHosts stores the per-host tokens in the store, replacing any previously stored ones.
+// Removes the entry altogether if there are no tokens.
+func (ts TokenStore) SetHosts(tokens credentials.Tokens) error {
+	if len(tokens) == 0 {
+		err := ts.Delete(hostsKey)
+		if errors.Is(err, ErrNotFound) {
+			return nil
 		}
+
+		return err
+	}
+
+	value, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("%s: encoding tokens: %w", hostsKey, err)
 	}
 
-	return errors.Join(errs...)
+	return ts.Set(hostsKey, string(value))
 }
@@ -0,0 +1,163 @@
+package tokenstore_test
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/tokenstore"
+	"github.com/idelchi/godyl/pkg/credentials"
+)
+
+// newFileStore returns a store backed by the token file at path.
+func newFileStore(path string) tokenstore.TokenStore {
+	return tokenstore.NewFile(path, func() (string, error) { return "passphrase", nil })
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tokens.age")
+	store := newFileStore(path)
+
+	if _, err := store.Get("github-token"); !errors.Is(err, tokenstore.ErrNotFound) {
+		t.Fatalf("Get() on empty store error = %v, want %v", err, tokenstore.ErrNotFound)
+	}
+
+	if err := store.SetAll(map[string]any{"github-token": "gh", "gitlab-token": "gl"}); err != nil {
+		t.Fatalf("SetAll() error = %v", err)
+	}
+
+	// A new store on the same file must decrypt what was written.
+	reopened := newFileStore(path)
+
+	tokens, err := reopened.GetAll("github-token", "gitlab-token", "url-token")
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+
+	if len(tokens) != 2 || tokens["github-token"] != "gh" || tokens["gitlab-token"] != "gl" {
+		t.Errorf("GetAll() = %v, want the github and gitlab tokens", tokens)
+	}
+
+	if err := reopened.Delete("github-token", "url-token"); !errors.Is(err, tokenstore.ErrNotFound) {
+		t.Errorf("Delete() of missing key error = %v, want %v", err, tokenstore.ErrNotFound)
+	}
+
+	if _, err := reopened.Get("github-token"); !errors.Is(err, tokenstore.ErrNotFound) {
+		t.Errorf("Get() of deleted key error = %v, want %v", err, tokenstore.ErrNotFound)
+	}
+
+	if err := reopened.Delete(); err != nil {
+		t.Fatalf("Delete() of all keys error = %v", err)
+	}
+
+	if _, err := reopened.Get("gitlab-token"); !errors.Is(err, tokenstore.ErrNotFound) {
+		t.Errorf("Get() after deleting all keys error = %v, want %v", err, tokenstore.ErrNotFound)
+	}
+}
+
+func TestFileStoreWrongPassphrase(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tokens.age")
+
+	store := newFileStore(path)
+	if err := store.Set("github-token", "gh"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	wrong := tokenstore.NewFile(path, func() (string, error) { return "wrong", nil })
+	if _, err := wrong.Get("github-token"); err == nil || errors.Is(err, tokenstore.ErrNotFound) {
+		t.Errorf("Get() with wrong passphrase error = %v, want a decryption error", err)
+	}
+}
+
+func TestMoveTo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		set   map[string]any
+		hosts credentials.Tokens
+		keys  []string
+		want  []string
+	}{
+		{
+			name: "nothing to move",
+			keys: []string{"github-token"},
+		},
+		{
+			name: "only requested keys",
+			set:  map[string]any{"github-token": "gh", "other": "kept"},
+			keys: []string{"github-token", "gitlab-token"},
+			want: []string{"github-token"},
+		},
+		{
+			name:  "host tokens along",
+			set:   map[string]any{"gitlab-token": "gl"},
+			hosts: credentials.Tokens{{Host: "example.com", Token: "ex"}},
+			keys:  []string{"gitlab-token"},
+			want:  []string{"gitlab-token", "host-tokens"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			from := newFileStore(filepath.Join(dir, "from.age"))
+			to := newFileStore(filepath.Join(dir, "to.age"))
+
+			if len(tc.set) > 0 {
+				if err := from.SetAll(tc.set); err != nil {
+					t.Fatalf("SetAll() error = %v", err)
+				}
+			}
+
+			if err := from.SetHosts(tc.hosts); err != nil {
+				t.Fatalf("SetHosts() error = %v", err)
+			}
+
+			moved, err := from.MoveTo(to, tc.keys...)
+			if err != nil {
+				t.Fatalf("MoveTo() error = %v", err)
+			}
+
+			if !slices.Equal(moved, tc.want) {
+				t.Errorf("MoveTo() = %v, want %v", moved, tc.want)
+			}
+
+			for _, key := range tc.want {
+				if _, err := from.Get(key); !errors.Is(err, tokenstore.ErrNotFound) {
+					t.Errorf("%q left in source store: %v", key, err)
+				}
+
+				if _, err := to.Get(key); err != nil {
+					t.Errorf("%q missing in target store: %v", key, err)
+				}
+			}
+
+			for key := range tc.set {
+				if slices.Contains(tc.want, key) {
+					continue
+				}
+
+				if _, err := from.Get(key); err != nil {
+					t.Errorf("%q not requested but removed from source store: %v", key, err)
+				}
+			}
+
+			hosts, err := to.GetHosts()
+			if err != nil {
+				t.Fatalf("GetHosts() error = %v", err)
+			}
+
+			if len(hosts) != len(tc.hosts) {
+				t.Errorf("GetHosts() = %v, want %v", hosts, tc.hosts)
+			}
+		})
+	}
+}
@@ -2,14 +2,13 @@
 package checksum
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/goccy/go-yaml/ast"
 
-	"github.com/idelchi/godyl/internal/data"
-	"github.com/idelchi/godyl/pkg/download"
 	"github.com/idelchi/godyl/pkg/path/file"
 	"github.com/idelchi/godyl/pkg/unmarshal"
 )
@@ -70,7 +69,7 @@ func (c *Checksum) IsMandatory() bool {
 // Resolve determines the actual checksum value based on its type and value.
 //
 //nolint:gocognit,funlen // TODO(Idelchi): Refactor this whole package
-func (c *Checksum) Resolve(skipVerifySSL bool) error {
+func (c *Checksum) Resolve(ctx context.Context, skipVerifySSL bool) error {
 	// For none and file type, do nothing
 	if c.Type == None {
 		return nil
@@ -97,29 +96,9 @@ func (c *Checksum) Resolve(skipVerifySSL bool) error {
 
 	//nolint:nestif // TODO(Idelchi): Refactor this whole package
 	if url, ok := strings.CutPrefix(c.Value, "url:"); ok {
-		options := []download.Option{}
-
-		if skipVerifySSL {
-			options = append(options, download.WithInsecureSkipVerify())
-		}
-
-		dir, err := data.CreateUniqueDirIn()
-		if err != nil {
-			return fmt.Errorf("creating random dir: %w", err)
-		}
-
-		defer func() {
-			err = errors.Join(err, dir.Remove())
-		}()
-
-		checksum, err := download.New(options...).Download(url, dir.Path())
-		if err != nil {
-			return fmt.Errorf("downloading checksum from %q: %w", url, err)
-		}
-
-		bytes, err := checksum.Read()
+		bytes, err := Fetch(ctx, url, skipVerifySSL)
 		if err != nil {
-			return fmt.Errorf("reading checksum file from path %q: %w", dir.Path(), err)
+			return err
 		}
 
 		content := string(bytes)
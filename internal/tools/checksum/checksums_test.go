@@ -394,7 +394,7 @@ func TestChecksumResolve(t *testing.T) {
 			t.Parallel()
 
 			c := tc.input
-			err := c.Resolve(false)
+			err := c.Resolve(t.Context(), false)
 
 			if tc.wantErr != "" {
 				if err == nil {
@@ -441,7 +441,7 @@ func TestChecksumResolvePath(t *testing.T) {
 
 		c := checksum.Checksum{Type: checksum.SHA256, Value: "path:" + checksumFile, Entry: "tool.tar.gz"}
 
-		if err := c.Resolve(false); err != nil {
+		if err := c.Resolve(t.Context(), false); err != nil {
 			t.Fatalf("Resolve() unexpected error: %v", err)
 		}
 
@@ -462,7 +462,7 @@ func TestChecksumResolvePath(t *testing.T) {
 
 		c := checksum.Checksum{Type: checksum.SHA256, Value: "path:" + checksumFile}
 
-		if err := c.Resolve(false); err != nil {
+		if err := c.Resolve(t.Context(), false); err != nil {
 			t.Fatalf("Resolve() unexpected error: %v", err)
 		}
 
@@ -483,7 +483,7 @@ func TestChecksumResolvePath(t *testing.T) {
 
 		c := checksum.Checksum{Type: checksum.SHA256, Value: "path:" + checksumFile, Entry: "missing.tar.gz"}
 
-		if err := c.Resolve(false); err == nil {
+		if err := c.Resolve(t.Context(), false); err == nil {
 			t.Fatal("Resolve() expected error for missing entry, got nil")
 		}
 	})
@@ -493,7 +493,7 @@ func TestChecksumResolvePath(t *testing.T) {
 
 		c := checksum.Checksum{Type: checksum.SHA256, Value: "path:/nonexistent/file.txt"}
 
-		if err := c.Resolve(false); err == nil {
+		if err := c.Resolve(t.Context(), false); err == nil {
 			t.Fatal("Resolve() expected error for nonexistent path, got nil")
 		}
 	})
@@ -518,7 +518,7 @@ func TestChecksumResolveURL(t *testing.T) {
 			Entry: "tool-linux-amd64.tar.gz",
 		}
 
-		if err := c.Resolve(false); err != nil {
+		if err := c.Resolve(t.Context(), false); err != nil {
 			t.Fatalf("Resolve() unexpected error: %v", err)
 		}
 
@@ -540,7 +540,7 @@ func TestChecksumResolveURL(t *testing.T) {
 			Value: "url:" + srv.URL + "/sha.txt",
 		}
 
-		if err := c.Resolve(false); err != nil {
+		if err := c.Resolve(t.Context(), false); err != nil {
 			t.Fatalf("Resolve() unexpected error: %v", err)
 		}
 
@@ -563,7 +563,7 @@ func TestChecksumResolveURL(t *testing.T) {
 			Entry: "missing.tar.gz",
 		}
 
-		if err := c.Resolve(false); err == nil {
+		if err := c.Resolve(t.Context(), false); err == nil {
 			t.Fatal("Resolve() expected error for missing entry, got nil")
 		}
 	})
@@ -0,0 +1,180 @@
+package checksum
+
+import (
+	"context"
+	"crypto/md5"  //nolint:gosec	// MD5 checksums are still published by some vendors.
+	"crypto/sha1" //nolint:gosec	// SHA1 checksums are still published by some vendors.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/idelchi/godyl/internal/data"
+	"github.com/idelchi/godyl/internal/mirrors"
+	"github.com/idelchi/godyl/internal/network"
+	"github.com/idelchi/godyl/pkg/download"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+// ErrNoChecksum is returned when no checksum is available to verify a file against.
+var ErrNoChecksum = errors.New("no checksum available")
+
+// Digest returns the type and the value of the checksum of the file with the name,
+// fetching and searching the checksum file for checksums of type file.
+// The checksum is expected to be resolved.
+func (c *Checksum) Digest(ctx context.Context, name string, skipVerifySSL bool) (Type, string, error) {
+	switch c.Type {
+	case None:
+		return None, "", ErrNoChecksum
+	case SHA256, SHA512, SHA1, MD5:
+		if c.Value == "" {
+			return None, "", ErrNoChecksum
+		}
+
+		return c.Type, strings.ToLower(c.Value), nil
+	case File:
+	default:
+		return None, "", fmt.Errorf("unsupported checksum type %q", c.Type)
+	}
+
+	if c.Value == "" {
+		return None, "", ErrNoChecksum
+	}
+
+	var (
+		content []byte
+		err     error
+	)
+
+	if local, ok := strings.CutPrefix(c.Value, "path:"); ok {
+		content, err = file.New(local).Read()
+	} else {
+		content, err = Fetch(ctx, strings.TrimPrefix(c.Value, "url:"), skipVerifySSL)
+	}
+
+	if err != nil {
+		return None, "", err
+	}
+
+	typ, value, err := Lookup(content, name)
+	if err != nil {
+		return None, "", fmt.Errorf("%w in checksum file %q", err, c.Value)
+	}
+
+	return typ, value, nil
+}
+
+// Lookup returns the type and the value of the checksum of the file with the name from the contents of a checksum file.
+// Entries are matched by their name, or by their base name for entries listed with the path they were created from.
+func Lookup(content []byte, name string) (Type, string, error) {
+	checksums := ParseChecksumFile(string(content))
+
+	value, ok := checksums[name]
+	if !ok {
+		for entry, checksum := range checksums {
+			if path.Base(entry) == name {
+				value, ok = checksum, true
+
+				break
+			}
+		}
+	}
+
+	if !ok {
+		return None, "", fmt.Errorf("%w: entry %q not found", ErrNoChecksum, name)
+	}
+
+	value = strings.ToLower(value)
+
+	typ, err := typeOf(value)
+	if err != nil {
+		return None, "", err
+	}
+
+	return typ, value, nil
+}
+
+// Sum returns the checksum of the contents of the reader, as a lowercase hex string.
+func (t Type) Sum(r io.Reader) (string, error) {
+	var h hash.Hash
+
+	switch t {
+	case SHA256:
+		h = sha256.New()
+	case SHA512:
+		h = sha512.New()
+	case SHA1:
+		h = sha1.New() //nolint:gosec	// MD5 and SHA1 are only used to match published checksums.
+	case MD5:
+		h = md5.New() //nolint:gosec	// MD5 and SHA1 are only used to match published checksums.
+	default:
+		return "", fmt.Errorf("unsupported checksum type %q", t)
+	}
+
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("hashing: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// typeOf returns the type of the hex encoded checksum, by its length.
+func typeOf(value string) (Type, error) {
+	const (
+		md5Length    = 32
+		sha1Length   = 40
+		sha256Length = 64
+		sha512Length = 128
+	)
+
+	switch len(value) {
+	case md5Length:
+		return MD5, nil
+	case sha1Length:
+		return SHA1, nil
+	case sha256Length:
+		return SHA256, nil
+	case sha512Length:
+		return SHA512, nil
+	default:
+		return None, fmt.Errorf("unrecognized checksum %q", value)
+	}
+}
+
+// Fetch downloads the file from the URL, such as a checksum file or its signature, and returns its contents.
+func Fetch(ctx context.Context, url string, skipVerifySSL bool) (content []byte, err error) {
+	policy := network.PolicyFrom(ctx)
+
+	options := []download.Option{
+		download.WithMirrors(mirrors.Rules()),
+		download.WithTransport(network.TransportFor(skipVerifySSL)),
+		download.WithMaxRetries(policy.Retries),
+		download.WithRetryWaits(policy.WaitMin, policy.WaitMax),
+	}
+
+	dir, err := data.CreateUniqueDirIn()
+	if err != nil {
+		return nil, fmt.Errorf("creating random dir: %w", err)
+	}
+
+	defer func() {
+		err = errors.Join(err, dir.Remove())
+	}()
+
+	checksum, err := download.New(options...).Download(ctx, url, dir.Path())
+	if err != nil {
+		return nil, fmt.Errorf("downloading %q: %w", url, err)
+	}
+
+	content, err = checksum.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading downloaded file from path %q: %w", dir.Path(), err)
+	}
+
+	return content, nil
+}
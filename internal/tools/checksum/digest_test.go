@@ -0,0 +1,126 @@
+package checksum_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/tools/checksum"
+)
+
+func TestChecksumDigest(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sha256 = "ABC123abc123abc123abc123abc123abc123abc123abc123abc123abc123abcd"
+		md5    = "d41d8cd98f00b204e9800998ecf8427e"
+	)
+
+	checksums := filepath.Join(t.TempDir(), "checksums.txt")
+
+	content := strings.Join([]string{
+		sha256 + "  dist/tool_linux_amd64.tar.gz",
+		md5 + "  tool_darwin_arm64.zip",
+	}, "\n")
+
+	if err := os.WriteFile(checksums, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		checksum checksum.Checksum
+		file     string
+		wantType checksum.Type
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "hash value is returned lowercased",
+			checksum: checksum.Checksum{Type: checksum.SHA256, Value: sha256},
+			file:     "anything",
+			wantType: checksum.SHA256,
+			want:     strings.ToLower(sha256),
+		},
+		{
+			name:     "entry matched by base name infers sha256",
+			checksum: checksum.Checksum{Type: checksum.File, Value: "path:" + checksums},
+			file:     "tool_linux_amd64.tar.gz",
+			wantType: checksum.SHA256,
+			want:     strings.ToLower(sha256),
+		},
+		{
+			name:     "entry matched exactly infers md5",
+			checksum: checksum.Checksum{Type: checksum.File, Value: "path:" + checksums},
+			file:     "tool_darwin_arm64.zip",
+			wantType: checksum.MD5,
+			want:     md5,
+		},
+		{
+			name:     "missing entry",
+			checksum: checksum.Checksum{Type: checksum.File, Value: "path:" + checksums},
+			file:     "tool_windows_amd64.zip",
+			wantErr:  checksum.ErrNoChecksum,
+		},
+		{
+			name:     "type none",
+			checksum: checksum.Checksum{Type: checksum.None},
+			file:     "anything",
+			wantErr:  checksum.ErrNoChecksum,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			typ, got, err := tt.checksum.Digest(t.Context(), tt.file, false)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Digest() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Digest() unexpected error: %v", err)
+			}
+
+			if typ != tt.wantType || got != tt.want {
+				t.Errorf("Digest() = %s:%s, want %s:%s", typ, got, tt.wantType, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeSum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ  checksum.Type
+		want string
+	}{
+		{checksum.SHA256, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{checksum.SHA1, "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+		{checksum.MD5, "d41d8cd98f00b204e9800998ecf8427e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.typ.Sum(strings.NewReader(""))
+			if err != nil {
+				t.Fatalf("Sum() unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sum() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Package drift detects how installed tools differ from their desired state and from what godyl installed.
+package drift
+
+import (
+	"fmt"
+
+	"github.com/idelchi/godyl/internal/cache"
+	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/version"
+)
+
+// State is the state of an installed tool.
+type State string
+
+const (
+	// Missing is a tool that is not installed.
+	Missing State = "missing"
+	// Modified is a tool whose file was replaced or changed since godyl installed it.
+	Modified State = "modified"
+	// Unmanaged is a tool that is installed, but not by godyl.
+	Unmanaged State = "unmanaged"
+	// Unknown is a tool whose installed or target version cannot be determined or compared.
+	Unknown State = "unknown"
+	// Outdated is a tool whose installed version differs from the target version.
+	Outdated State = "outdated"
+	// UpToDate is a tool whose installed version matches the target version.
+	UpToDate State = "up_to_date"
+)
+
+// States lists all states, from the most to the least severe.
+var States = []State{Missing, Modified, Unmanaged, Unknown, Outdated, UpToDate}
+
+// Report is the state of an installed tool.
+type Report struct {
+	// Tool is the name of the tool.
+	Tool string `json:"tool"`
+	// Path is the path of the installed tool.
+	Path string `json:"path"`
+	// Installed is the installed version, if known.
+	Installed string `json:"installed,omitempty"`
+	// Target is the target version, if known.
+	Target string `json:"target,omitempty"`
+	// State is the state of the tool.
+	State State `json:"state"`
+	// Message describes the state.
+	Message string `json:"message"`
+}
+
+// Detector determines the state of installed tools.
+type Detector struct {
+	cache *cache.Cache
+}
+
+// New returns a detector checking the installed tools against the cache of the tools installed by godyl.
+// Without a cache, tools are neither known to be managed by godyl nor to be modified since.
+func New(cache *cache.Cache) *Detector {
+	return &Detector{cache: cache}
+}
+
+// Detect determines the state of the resolved tool.
+func (d *Detector) Detect(t *tool.Tool) Report {
+	report := Report{
+		Tool:   t.Name,
+		Path:   t.Executable().Absolute().Path(),
+		Target: t.Version.Version,
+	}
+
+	if !t.Exists() {
+		return report.with(Missing, "not installed")
+	}
+
+	var item *cache.Item
+
+	if d.cache != nil {
+		items, err := d.cache.Get(t.ID())
+		if err != nil {
+			return report.with(Unmanaged, "not installed by godyl")
+		}
+
+		item = items[0]
+
+		if changed, reason := modified(file.New(report.Path), item); changed {
+			report.Installed = item.Version.Version
+
+			return report.with(Modified, reason)
+		}
+	}
+
+	if item != nil {
+		report.Installed = item.Version.Version
+	}
+
+	if report.Installed == "" {
+		report.Installed = t.GetCurrentVersion()
+	}
+
+	switch {
+	case report.Installed == "":
+		return report.with(Unknown, "installed version cannot be determined")
+	case report.Target == "":
+		return report.with(Unknown, "target version cannot be determined")
+	}
+
+	installed, target := version.Parse(report.Installed), version.Parse(report.Target)
+
+	switch {
+	case installed != nil && target != nil && installed.Equal(target), report.Installed == report.Target:
+		return report.with(UpToDate, "up to date")
+	case installed == nil || target == nil:
+		return report.with(Unknown, fmt.Sprintf("versions %q and %q cannot be compared", report.Installed, report.Target))
+	default:
+		return report.with(Outdated, fmt.Sprintf("%s → %s", report.Installed, report.Target))
+	}
+}
+
+// with returns the report with the state and message set.
+func (r Report) with(state State, message string) Report {
+	r.State = state
+	r.Message = message
+
+	return r
+}
+
+// modified reports whether the installed file differs from the one godyl installed, and why.
+// Items cached before the file was remembered are never considered modified.
+func modified(installed file.File, item *cache.Item) (bool, string) {
+	if item.Hash == "" {
+		return false, ""
+	}
+
+	since := "since installed"
+	if !item.Updated.IsZero() {
+		since += " on " + item.Updated.Local().Format("2006-01-02 15:04")
+	}
+
+	info, err := installed.Info()
+	if err != nil {
+		return true, "cannot be inspected: " + err.Error()
+	}
+
+	if item.Size > 0 && info.Size() != item.Size {
+		return true, "size changed " + since
+	}
+
+	hash, err := installed.Hash()
+	if err != nil {
+		return true, "cannot be inspected: " + err.Error()
+	}
+
+	if hash != item.Hash {
+		return true, "contents changed " + since
+	}
+
+	return false, ""
+}
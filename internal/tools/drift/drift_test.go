@@ -0,0 +1,99 @@
+package drift_test
+
+import (
+	"testing"
+
+	"github.com/idelchi/godyl/internal/cache"
+	"github.com/idelchi/godyl/internal/tools/drift"
+	"github.com/idelchi/godyl/internal/tools/exe"
+	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/internal/tools/version"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	const contents = "#!/bin/sh\necho installed\n"
+
+	tests := []struct {
+		name     string
+		target   string
+		cached   string // version in the cache, if the tool is cached
+		cache    bool   // whether to cache the tool
+		install  bool   // whether to install the tool
+		modify   bool   // whether to modify the tool after caching it
+		disabled bool   // whether to detect without a cache
+		want     drift.State
+	}{
+		{name: "missing", target: "1.0.0", cache: true, want: drift.Missing},
+		{name: "up to date", target: "v1.0.0", cached: "1.0.0", cache: true, install: true, want: drift.UpToDate},
+		{name: "outdated", target: "1.1.0", cached: "1.0.0", cache: true, install: true, want: drift.Outdated},
+		{name: "modified", target: "1.0.0", cached: "1.0.0", cache: true, install: true, modify: true, want: drift.Modified},
+		{name: "unmanaged", target: "1.0.0", install: true, want: drift.Unmanaged},
+		{name: "version unknown", target: "1.0.0", cache: true, install: true, want: drift.Unknown},
+		{name: "target unknown", cached: "1.0.0", cache: true, install: true, want: drift.Unknown},
+		{name: "without cache", target: "1.0.0", install: true, disabled: true, want: drift.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			tl := &tool.Tool{
+				Name:    "owner/tool",
+				Output:  dir,
+				Exe:     exe.Exe{Name: "tool"},
+				Version: version.Version{Version: tt.target},
+			}
+
+			installed := file.New(dir, "tool")
+
+			if tt.install {
+				if err := installed.Write([]byte(contents)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			c := cache.New(file.New(dir, "cache.json"))
+			if err := c.Load(); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.cache {
+				item := &cache.Item{ID: tl.ID(), Name: tl.Name, Version: version.Version{Version: tt.cached}}
+
+				if tt.install {
+					hash, err := installed.Hash()
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					item.Hash, item.Size = hash, int64(len(contents))
+				}
+
+				if err := c.Add(item); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.modify {
+				if err := installed.Write([]byte(contents + "tampered\n")); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.disabled {
+				c = nil
+			}
+
+			report := drift.New(c).Detect(tl)
+
+			if report.State != tt.want {
+				t.Errorf("Detect() state = %q (%s), want %q", report.State, report.Message, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package github
+
+// ResolveToken exports the unexported token for use in tests.
+func ResolveToken(g *GitHub) string {
+	return g.token()
+}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -13,17 +14,25 @@ import (
 	"github.com/idelchi/godyl/internal/match"
 	"github.com/idelchi/godyl/internal/release"
 	"github.com/idelchi/godyl/internal/tools/sources/install"
+	"github.com/idelchi/godyl/pkg/credentials"
 	"github.com/idelchi/godyl/pkg/path/file"
 )
 
+// server is the default GitHub server.
+const server = "https://github.com"
+
 // GitHub represents a GitHub repository configuration and state.
 type GitHub struct {
 	Data                install.Metadata `mapstructure:"-" yaml:"-"`
 	latestStoredRelease *release.Release
-	Repo                string `mapstructure:"repo"  yaml:"repo"`
-	Owner               string `mapstructure:"owner" yaml:"owner"`
-	Token               string `mapstructure:"token" mask:"fixed" yaml:"token"`
-	Pre                 bool   `mapstructure:"pre"   yaml:"pre"`
+	prefetched          *release.Release
+	Repo                string `mapstructure:"repo"   yaml:"repo"`
+	Owner               string `mapstructure:"owner"  yaml:"owner"`
+	Token               string `mapstructure:"token"  mask:"fixed" yaml:"token"`
+	Server              string `mapstructure:"server" yaml:"server"`
+	Pre                 bool   `mapstructure:"pre"    yaml:"pre"`
+	tokens              credentials.Tokens
+	chain               []string
 }
 
 // Initialize sets up the GitHub repository configuration from the given name.
@@ -39,9 +48,7 @@ func (g *GitHub) Initialize(name string) error {
 }
 
 // Version fetches the latest release version and stores it in metadata.
-func (g *GitHub) Version(version string) error {
-	ctx := context.Background()
-
+func (g *GitHub) Version(ctx context.Context, version string) error {
 	version, err := g.LatestVersion(ctx, version)
 	if err != nil {
 		return err
@@ -54,9 +61,13 @@ func (g *GitHub) Version(version string) error {
 
 // URL finds a matching release asset and stores its URL in metadata.
 // Uses version, extensions, and requirements to find the appropriate asset.
-func (g *GitHub) URL(_ string, extensions []string, version string, requirements match.Requirements) error {
-	ctx := context.Background()
-
+func (g *GitHub) URL(
+	ctx context.Context,
+	_ string,
+	extensions []string,
+	version string,
+	requirements match.Requirements,
+) error {
 	url, err := g.MatchAssetsToRequirements(ctx, extensions, version, requirements)
 	if err != nil {
 		return err
@@ -70,13 +81,15 @@ func (g *GitHub) URL(_ string, extensions []string, version string, requirements
 // Install downloads the GitHub release asset using the provided configuration.
 // Returns the operation output, downloaded file information, and any errors.
 func (g *GitHub) Install(
+	ctx context.Context,
 	d install.Data,
 	progressListener getter.ProgressTracker,
 ) (output string, found file.File, err error) {
 	// Pass the progress listener down to the common download function
 	d.ProgressListener = progressListener
+	d.Tokens = g.tokens
 
-	found, err = install.Download(d)
+	found, err = install.Download(ctx, d)
 
 	return "", found, err
 }
@@ -89,7 +102,7 @@ func (g *GitHub) Get(attribute string) string {
 // LatestVersion fetches the latest release version from GitHub.
 // Returns the tag name of the latest release, respecting the Pre flag setting.
 func (g *GitHub) LatestVersion(ctx context.Context, version string) (string, error) {
-	client := github.NewClient(g.Token)
+	client := github.NewClient(g.token(), g.apiURL())
 	repository := github.NewRepository(g.Owner, g.Repo, client)
 
 	var release *release.Release
@@ -109,8 +122,10 @@ func (g *GitHub) LatestVersion(ctx context.Context, version string) (string, err
 			ctx,
 			PerPage,
 		)
+	case g.prefetched != nil:
+		release = g.prefetched
 	default:
-		if g.Token == "" {
+		if g.token() == "" && g.Server == "" {
 			if tag, webErr := repository.LatestVersionFromWebJSON(ctx); webErr == nil {
 				return tag, nil
 			}
@@ -139,7 +154,7 @@ func (g *GitHub) MatchAssetsToRequirements(
 	version string,
 	requirements match.Requirements,
 ) (string, error) {
-	client := github.NewClient(g.Token)
+	client := github.NewClient(g.token(), g.apiURL())
 	repository := github.NewRepository(g.Owner, g.Repo, client)
 
 	var release *release.Release
@@ -147,7 +162,7 @@ func (g *GitHub) MatchAssetsToRequirements(
 	if g.latestStoredRelease == nil { //nolint:nestif // Multiple checks are necessary
 		var err error
 
-		if g.Token == "" {
+		if g.token() == "" && g.Server == "" {
 			release, err = repository.GetReleaseFromWeb(ctx, version)
 		}
 
@@ -182,21 +197,30 @@ func (g *GitHub) MatchAssetsToRequirements(
 
 	asset := assets.FilterByName(matches[0].Asset.Name)[0]
 
-	// Check inline digest
-	if asset.Digest != "" {
-		debug.Debug("found asset with digest: %q", asset.Digest)
-		g.Data.Set("checksum", asset.Digest)
-	} else if checksums := assets.Checksums(requirements.Checksum); len(checksums) > 0 {
+	// Remember the checksum file even when the digest is used, as its signature may be verified
+	// Forget the checksums of previously matched assets
+	g.Data.Set("checksum", "")
+	g.Data.Set("checksum-file", "")
+
+	if checksums := assets.Checksums(requirements.Checksum); len(checksums) > 0 {
 		debug.Debug("found checksum assets: %q", checksums)
 
 		preferred := checksums.Preferred(asset.Name)
 		if preferred != "" {
 			checksum := assets.FilterByName(preferred)[0]
-			g.Data.Set("checksum", checksum.URL)
+			g.Data.Set("checksum-file", checksum.URL)
 			debug.Debug("using preferred checksum asset: %q from %q", checksum.URL, asset.Name)
 		}
 	}
 
+	// Check inline digest
+	if asset.Digest != "" {
+		debug.Debug("found asset with digest: %q", asset.Digest)
+		g.Data.Set("checksum", asset.Digest)
+	} else if file := g.Data.Get("checksum-file"); file != "" {
+		g.Data.Set("checksum", file)
+	}
+
 	return asset.URL, nil
 }
 
@@ -221,3 +245,73 @@ func (g *GitHub) PopulateOwnerAndRepo(name string) (err error) {
 
 	return nil
 }
+
+// Batchable returns the repository and token to look up the latest release with ahead of the resolution,
+// if the latest release is retrieved from github.com with a token.
+// Templated fields are only known during the resolution, so they rule out looking up the release ahead of it.
+func (g *GitHub) Batchable(name string) (repository github.Name, token string, ok bool) {
+	if g.Pre || (g.Server != "" && strings.TrimSuffix(g.Server, "/") != server) {
+		return repository, "", false
+	}
+
+	for _, field := range []string{name, g.Owner, g.Repo, g.Token} {
+		if strings.Contains(field, "{{") {
+			return repository, "", false
+		}
+	}
+
+	candidate := *g
+	if err := candidate.PopulateOwnerAndRepo(name); err != nil {
+		return repository, "", false
+	}
+
+	token = candidate.token()
+	if token == "" {
+		return repository, "", false
+	}
+
+	return github.Name{Owner: candidate.Owner, Repo: candidate.Repo}, token, true
+}
+
+// UseLatestRelease sets the latest release looked up ahead of the resolution,
+// such that it is not retrieved again.
+func (g *GitHub) UseLatestRelease(release *release.Release) {
+	g.prefetched = release
+}
+
+// SetTokens sets the per-host tokens to look up the token for the server with.
+func (g *GitHub) SetTokens(tokens credentials.Tokens) {
+	g.tokens = tokens
+}
+
+// SetChain sets the credential helpers to query for a token for a self-hosted server.
+func (g *GitHub) SetChain(chain []string) {
+	g.chain = chain
+}
+
+// token returns the token configured for the server and repository,
+// then `token`, and the credential helpers only for a self-hosted server without a configured token.
+func (g *GitHub) token() string {
+	base := strings.TrimSuffix(cmp.Or(g.Server, server), "/")
+
+	if token, ok := g.tokens.Lookup(base + "/" + g.Owner + "/" + g.Repo); ok {
+		return token
+	}
+
+	if g.Token != "" || g.Server == "" || g.Server == server {
+		return g.Token
+	}
+
+	token, _, _ := credentials.Fill(g.chain, g.Server)
+
+	return token
+}
+
+// apiURL returns the API endpoint of the server, or an empty string for github.com.
+func (g *GitHub) apiURL() string {
+	if g.Server == "" {
+		return ""
+	}
+
+	return strings.TrimSuffix(g.Server, "/") + "/api/v3/"
+}
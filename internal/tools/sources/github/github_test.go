@@ -0,0 +1,41 @@
+package github_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/tools/sources/github"
+	"github.com/idelchi/godyl/pkg/credentials"
+)
+
+func TestToken(t *testing.T) {
+	netrc := filepath.Join(t.TempDir(), ".netrc")
+	if err := os.WriteFile(netrc, []byte("machine github.example.com password from-netrc\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("NETRC", netrc)
+
+	tests := []struct {
+		name   string
+		server string
+		token  string
+		want   string
+	}{
+		{name: "configured token wins over the helpers", server: "https://github.example.com", token: "configured", want: "configured"},
+		{name: "helpers without a configured token", server: "https://github.example.com", want: "from-netrc"},
+		{name: "helpers not queried for github.com", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &github.GitHub{Server: tt.server, Token: tt.token, Owner: "owner", Repo: "repo"}
+			g.SetChain([]string{credentials.Netrc})
+
+			if got := github.ResolveToken(g); got != tt.want {
+				t.Errorf("token() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package gitlab
+
+// ResolveToken exports the unexported token for use in tests.
+func ResolveToken(g *GitLab) string {
+	return g.token()
+}
@@ -1,21 +1,28 @@
 package gitlab
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/go-getter/v2"
 
 	"github.com/idelchi/godyl/internal/debug"
 	"github.com/idelchi/godyl/internal/gitlab"
 	"github.com/idelchi/godyl/internal/match"
+	"github.com/idelchi/godyl/internal/network"
 	"github.com/idelchi/godyl/internal/release"
 	"github.com/idelchi/godyl/internal/tools/sources/install"
+	"github.com/idelchi/godyl/pkg/credentials"
 	"github.com/idelchi/godyl/pkg/path/file"
 )
 
+// server is the default GitLab server.
+const server = "https://gitlab.com"
+
 // GitLab represents a GitLab project configuration and state.
 type GitLab struct {
 	Data                install.Metadata `mapstructure:"-" yaml:"-"`
@@ -26,6 +33,8 @@ type GitLab struct {
 	Server              string `mapstructure:"server"    yaml:"server"`
 	Pre                 bool   `mapstructure:"pre"       yaml:"pre"`
 	NoToken             bool   `mapstructure:"no-token"  yaml:"no-token"`
+	tokens              credentials.Tokens
+	chain               []string
 }
 
 // Initialize sets up the GitLab project configuration from the given name.
@@ -45,9 +54,7 @@ func (g *GitLab) Initialize(name string) error {
 }
 
 // Version fetches the latest release version and stores it in metadata.
-func (g *GitLab) Version(_ string) error {
-	ctx := context.Background()
-
+func (g *GitLab) Version(ctx context.Context, _ string) error {
 	version, err := g.LatestVersion(ctx)
 	if err != nil {
 		return err
@@ -60,9 +67,13 @@ func (g *GitLab) Version(_ string) error {
 
 // URL finds a matching release asset and stores its URL in metadata.
 // Uses version, extensions, and requirements to find the appropriate asset.
-func (g *GitLab) URL(_ string, extensions []string, version string, requirements match.Requirements) error {
-	ctx := context.Background()
-
+func (g *GitLab) URL(
+	ctx context.Context,
+	_ string,
+	extensions []string,
+	version string,
+	requirements match.Requirements,
+) error {
 	url, err := g.MatchAssetsToRequirements(ctx, extensions, version, requirements)
 	if err != nil {
 		return err
@@ -76,14 +87,20 @@ func (g *GitLab) URL(_ string, extensions []string, version string, requirements
 // Install downloads the GitLab release asset using the provided configuration.
 // Returns the operation output, downloaded file information, and any errors.
 func (g *GitLab) Install(
+	ctx context.Context,
 	d install.Data,
 	progressListener getter.ProgressTracker,
 ) (output string, found file.File, err error) {
 	d.Header = g.GetHeaders()
+
+	if !g.NoToken {
+		d.Tokens = g.tokens
+	}
+
 	// Pass the progress listener down
 	d.ProgressListener = progressListener
 
-	found, err = install.Download(d)
+	found, err = install.Download(ctx, d)
 
 	return "", found, err
 }
@@ -96,7 +113,7 @@ func (g *GitLab) Get(attribute string) string {
 // LatestVersion fetches the latest release version from GitLab.
 // Returns the tag name of the latest release, respecting the Pre flag setting.
 func (g *GitLab) LatestVersion(ctx context.Context) (string, error) {
-	client, err := gitlab.NewClient(g.Token, g.Server)
+	client, err := gitlab.NewClient(g.token(), g.Server, network.PolicyFrom(ctx))
 	if err != nil {
 		return "", fmt.Errorf("creating GitLab client: %w", err)
 	}
@@ -132,7 +149,7 @@ func (g *GitLab) MatchAssetsToRequirements(
 	version string,
 	requirements match.Requirements,
 ) (string, error) {
-	client, err := gitlab.NewClient(g.Token, g.Server)
+	client, err := gitlab.NewClient(g.token(), g.Server, network.PolicyFrom(ctx))
 	if err != nil {
 		return "", fmt.Errorf("creating GitLab client: %w", err)
 	}
@@ -164,6 +181,10 @@ func (g *GitLab) MatchAssetsToRequirements(
 
 	asset := assets.FilterByName(matches[0].Asset.Name)[0]
 
+	// Forget the checksums of previously matched assets
+	g.Data.Set("checksum", "")
+	g.Data.Set("checksum-file", "")
+
 	if checksums := assets.Checksums(requirements.Checksum); len(checksums) > 0 {
 		debug.Debug("found checksum assets: %q", checksums)
 
@@ -171,6 +192,7 @@ func (g *GitLab) MatchAssetsToRequirements(
 		if preferred != "" {
 			checksum := assets.FilterByName(preferred)[0]
 			g.Data.Set("checksum", checksum.URL)
+			g.Data.Set("checksum-file", checksum.URL)
 			debug.Debug("using preferred checksum asset: %q from %q", checksum.URL, asset.Name)
 		}
 	}
@@ -209,6 +231,38 @@ func (g *GitLab) GetHeaders() http.Header {
 	}
 
 	return http.Header{
-		"PRIVATE-TOKEN": []string{g.Token},
+		"PRIVATE-TOKEN": []string{g.token()},
+	}
+}
+
+// SetTokens sets the per-host tokens to look up the token for the server with.
+func (g *GitLab) SetTokens(tokens credentials.Tokens) {
+	g.tokens = tokens
+}
+
+// SetChain sets the credential helpers to query for a token for a self-hosted server.
+func (g *GitLab) SetChain(chain []string) {
+	g.chain = chain
+}
+
+// token returns the token configured for the server and project,
+// then `token`, and the credential helpers only for a self-hosted server without a configured token.
+func (g *GitLab) token() string {
+	if g.NoToken {
+		return ""
 	}
+
+	base := strings.TrimSuffix(cmp.Or(g.Server, server), "/")
+
+	if token, ok := g.tokens.Lookup(base + "/" + g.Namespace + "/" + g.Project); ok {
+		return token
+	}
+
+	if g.Token != "" || g.Server == "" || g.Server == server {
+		return g.Token
+	}
+
+	token, _, _ := credentials.Fill(g.chain, g.Server)
+
+	return token
 }
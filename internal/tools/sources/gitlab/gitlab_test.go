@@ -0,0 +1,41 @@
+package gitlab_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/tools/sources/gitlab"
+	"github.com/idelchi/godyl/pkg/credentials"
+)
+
+func TestToken(t *testing.T) {
+	netrc := filepath.Join(t.TempDir(), ".netrc")
+	if err := os.WriteFile(netrc, []byte("machine gitlab.example.com password from-netrc\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("NETRC", netrc)
+
+	tests := []struct {
+		name   string
+		server string
+		token  string
+		want   string
+	}{
+		{name: "configured token wins over the helpers", server: "https://gitlab.example.com", token: "configured", want: "configured"},
+		{name: "helpers without a configured token", server: "https://gitlab.example.com", want: "from-netrc"},
+		{name: "helpers not queried for gitlab.com", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &gitlab.GitLab{Server: tt.server, Token: tt.token, Namespace: "group", Project: "project"}
+			g.SetChain([]string{credentials.Netrc})
+
+			if got := gitlab.ResolveToken(g); got != tt.want {
+				t.Errorf("token() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -55,13 +55,13 @@ func (g *Go) Initialize(name string) error {
 }
 
 // Version fetches the latest release version and stores it in metadata.
-func (g *Go) Version(name string) error {
-	return g.github.Version(name)
+func (g *Go) Version(ctx context.Context, name string) error {
+	return g.github.Version(ctx, name)
 }
 
 // URL constructs and stores the Go module path in metadata.
 // Uses the format github.com/{owner}/{repo}@{version}.
-func (g *Go) URL(_ string, _ []string, version string, _ match.Requirements) error {
+func (g *Go) URL(_ context.Context, _ string, _ []string, version string, _ match.Requirements) error {
 	parts := []string{g.Base, g.github.Owner, g.github.Repo}
 
 	parts = slices.DeleteFunc(parts, func(s string) bool { return s == "" })
@@ -80,6 +80,7 @@ var mu sync.Mutex //nolint:gochecknoglobals // Global mutex for thread-safe acce
 //
 //nolint:funlen // TODO(Idelchi): Refactor later.
 func (g *Go) Install(
+	ctx context.Context,
 	d install.Data,
 	progressListener getter.ProgressTracker,
 ) (output string, found file.File, err error) {
@@ -87,7 +88,7 @@ func (g *Go) Install(
 
 	debug.Debug("Searching for go binary...")
 
-	binary, err := goi.New(d.NoVerifySSL, g.DownloadIfMissing, d.NoVerifyChecksum, progressListener)
+	binary, err := goi.New(ctx, d.NoVerifySSL, g.DownloadIfMissing, d.NoVerifyChecksum, d.Partials, progressListener)
 	if err != nil {
 		mu.Unlock()
 
@@ -143,6 +144,8 @@ func (g *Go) Install(
 		}
 	}
 
+	progresspkg.Enter(ctx, progresspkg.Building)
+
 	debug.Debug("Setting up progress tracker for 'go install'...")
 
 	stopProgress := startGoInstallProgress(progressListener, paths[0])
@@ -158,7 +161,7 @@ func (g *Go) Install(
 	for _, pth := range paths {
 		debug.Debug("Attempting to install from path: %q", pth)
 
-		output, err = installer.Install(pth)
+		output, err = installer.Install(ctx, pth)
 		debug.Debug("go install output: %q", output)
 		debug.Debug("successful path was: %q", pth)
 
@@ -192,8 +195,8 @@ func (g *Go) Install(
 			return output, found, findErr
 		}
 
-		// If timeout occurred, don't try other paths
-		if errors.Is(err, context.DeadlineExceeded) {
+		// If a timeout or interruption occurred, don't try other paths
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
 			return output, "", err
 		}
 	}
@@ -1,19 +1,27 @@
 package install
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-getter/v2"
 
 	"github.com/idelchi/godyl/internal/data"
+	"github.com/idelchi/godyl/internal/mirrors"
+	"github.com/idelchi/godyl/internal/network"
 	"github.com/idelchi/godyl/internal/tools/checksum"
+	"github.com/idelchi/godyl/pkg/binary"
+	"github.com/idelchi/godyl/pkg/credentials"
 	"github.com/idelchi/godyl/pkg/download"
 	"github.com/idelchi/godyl/pkg/env"
 	"github.com/idelchi/godyl/pkg/path/file"
 	"github.com/idelchi/godyl/pkg/path/folder"
+	"github.com/idelchi/godyl/pkg/version"
 )
 
 // Data contains configuration for downloading and installing tools.
@@ -22,6 +30,11 @@ type Data struct {
 	Env              env.Env
 	Checksum         checksum.Checksum
 	Header           http.Header
+	Tokens           credentials.Tokens
+	Archives         *download.ArchiveCache
+	Partials         folder.Folder
+	Shared           *Shared
+	Timeout          time.Duration
 	Path             string
 	Name             string
 	Exe              string
@@ -32,48 +45,97 @@ type Data struct {
 	NoVerifyChecksum bool
 	OS               string // Target operating system for cross-compilation.
 	Arch             string // Target architecture for cross-compilation.
+	Glibc            string // Glibc version of the target, to reject executables requiring a newer one.
 }
 
+// ErrGlibc is returned for executables requiring a newer glibc than the one of the target.
+var ErrGlibc = errors.New("executable requires a newer glibc")
+
 // Download retrieves files according to the InstallData configuration.
 // Creates temporary directories when needed, manages the download process,
 // and returns the download output and file information.
-func Download(d Data) (found file.File, err error) {
-	dir := folder.New(d.Output)
+// Downloads searched for the executable are shared with the other tools of the run, if enabled.
+func Download(ctx context.Context, d Data) (found file.File, err error) {
+	if d.Mode != "find" {
+		_, err := d.fetch(ctx, folder.New(d.Output))
+
+		return "", err
+	}
 
-	if d.Mode == "find" {
-		if dir, err = data.CreateUniqueDirIn(); err != nil {
-			return "", fmt.Errorf("creating random dir: %w", err)
+	if d.Shared != nil {
+		destination, err := d.Shared.Download(ctx, d.key(), d.fetch)
+		if err != nil {
+			return "", err
 		}
 
-		defer func() {
-			err = errors.Join(err, dir.Remove())
-		}()
+		return Find(destination, d)
+	}
+
+	dir, err := data.CreateUniqueDirIn()
+	if err != nil {
+		return "", fmt.Errorf("creating random dir: %w", err)
+	}
+
+	defer func() {
+		err = errors.Join(err, dir.Remove())
+	}()
+
+	destination, err := d.fetch(ctx, dir)
+	if err != nil {
+		return "", err
 	}
 
+	return Find(destination, d)
+}
+
+// fetch downloads the file into the directory and returns its destination.
+func (d Data) fetch(ctx context.Context, dir folder.Folder) (file.File, error) {
+	policy := network.PolicyFrom(ctx)
+
 	options := []download.Option{
 		download.WithProgress(d.ProgressListener),
-		download.WithContextTimeout(download.DefaultTimeout),
+		download.WithContextTimeout(cmp.Or(d.Timeout, download.DefaultTimeout)),
+		download.WithTokens(d.Tokens),
+		download.WithMirrors(mirrors.Rules()),
+		download.WithTransport(network.TransportFor(d.NoVerifySSL)),
+		download.WithMaxRetries(policy.Retries),
+		download.WithRetryWaits(policy.WaitMin, policy.WaitMax),
+	}
+
+	if d.Archives != nil {
+		options = append(options, download.WithArchiveCache(d.Archives))
 	}
-	if d.NoVerifySSL {
-		options = append(options, download.WithInsecureSkipVerify())
+
+	if d.Partials.IsSet() {
+		options = append(options, download.WithResume(d.Partials))
 	}
 
-	if d.Checksum.IsMandatory() && !d.NoVerifyChecksum {
+	if d.verifiesChecksum() {
 		options = append(options, download.WithChecksum(d.Checksum.ToQuery()))
 	}
 
 	downloader := download.New(options...)
 
-	destination, err := downloader.Download(d.Path, dir.Path(), d.Header)
+	destination, err := downloader.Download(ctx, d.Path, dir.Path(), d.Header)
 	if err != nil {
 		return "", fmt.Errorf("downloading %q: %w", d.Path, err)
 	}
 
-	if d.Mode == "find" {
-		found, err = Find(destination, d)
+	return destination, nil
+}
+
+// verifiesChecksum reports whether the checksum of the download is verified.
+func (d Data) verifiesChecksum() bool {
+	return d.Checksum.IsMandatory() && !d.NoVerifyChecksum
+}
+
+// key identifies the download to share it with other tools, by its URL and the checksum it is verified against.
+func (d Data) key() string {
+	if !d.verifiesChecksum() {
+		return d.Path
 	}
 
-	return found, err
+	return d.Path + "?" + d.Checksum.ToQuery()
 }
 
 // findExecutableInDir searches for an executable file in a directory using the provided patterns.
@@ -123,6 +185,10 @@ func Find(destination file.File, d Data) (file.File, error) {
 		}
 	}
 
+	if err := d.checkGlibc(destination); err != nil {
+		return destination, err
+	}
+
 	folder := folder.New(d.Output)
 	if !folder.Exists() {
 		if err := folder.Create(); err != nil {
@@ -130,10 +196,24 @@ func Find(destination file.File, d Data) (file.File, error) {
 		}
 	}
 
-	// Copy the executable to the output directory
+	// Copy the executable to a temporary file next to the target and move it in place,
+	// so that an interrupted copy never leaves a partially written executable behind.
 	target := file.New(d.Output, d.Exe)
-	if err := destination.Copy(target); err != nil {
-		return destination, fmt.Errorf("copying %q to %q: %w", destination, target, err)
+
+	tmp, err := file.CreateRandomInDir(d.Output, "."+d.Exe+".tmp-*")
+	if err != nil {
+		return destination, err
+	}
+
+	if err := destination.Copy(tmp); err != nil {
+		return destination, errors.Join(
+			fmt.Errorf("copying %q to %q: %w", destination, target, err),
+			tmp.Remove(),
+		)
+	}
+
+	if err := tmp.Rename(target); err != nil {
+		return destination, errors.Join(err, tmp.Remove())
 	}
 
 	if ok, _ := target.IsExecutable(); !ok {
@@ -144,3 +224,21 @@ func Find(destination file.File, d Data) (file.File, error) {
 
 	return destination, nil
 }
+
+// checkGlibc returns an ErrGlibc if the executable requires a newer glibc than the one of the target, if known.
+func (d Data) checkGlibc(executable file.File) error {
+	if d.Glibc == "" {
+		return nil
+	}
+
+	required, err := binary.RequiredGlibc(executable)
+	if err != nil {
+		return fmt.Errorf("inspecting %q: %w", executable, err)
+	}
+
+	if required != "" && version.LessThan(d.Glibc, required) {
+		return fmt.Errorf("%w: %q requires GLIBC_%s, the host has %s", ErrGlibc, executable.Base(), required, d.Glibc)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/idelchi/godyl/internal/data"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+// Shared shares the downloads of identical files between the tools of a run.
+// The first tool starts downloading and extracting the file, while all tools wait for it and search the same extracted tree.
+// The downloads are kept until Remove is called, such that tools installing the file later reuse them as well.
+type Shared struct {
+	downloads map[string]*sharedDownload
+	dirs      []folder.Folder
+	mu        sync.Mutex
+	wg        sync.WaitGroup
+}
+
+// sharedDownload is a download in progress or completed.
+type sharedDownload struct {
+	done        chan struct{}
+	cancel      context.CancelFunc
+	waiters     int
+	destination file.File
+	err         error
+}
+
+// NewShared returns an empty set of shared downloads.
+func NewShared() *Shared {
+	return &Shared{downloads: make(map[string]*sharedDownload)}
+}
+
+// Download returns the destination of the download with the key, calling fetch with a new directory to download into
+// unless the download is in progress or completed already.
+// The download is not bound to the context of the tool starting it, such that the tool being cancelled or timing out
+// does not fail the others waiting for it. It is cancelled once no tool waits for it anymore,
+// and is otherwise bounded by the timeout of fetch.
+// A failed download is reported to the tools waiting for it, while tools coming later try again.
+func (s *Shared) Download(
+	ctx context.Context,
+	key string,
+	fetch func(ctx context.Context, dir folder.Folder) (file.File, error),
+) (file.File, error) {
+	s.mu.Lock()
+
+	download, ok := s.downloads[key]
+	if !ok {
+		download = s.start(ctx, key, fetch)
+	}
+
+	download.waiters++
+
+	s.mu.Unlock()
+
+	select {
+	case <-download.done:
+		return download.destination, download.err
+	case <-ctx.Done():
+		s.leave(key, download)
+
+		return "", ctx.Err()
+	}
+}
+
+// start runs fetch for the download with the key in the background, detached from the cancellation of ctx.
+// It must be called with the lock held.
+func (s *Shared) start(
+	ctx context.Context,
+	key string,
+	fetch func(ctx context.Context, dir folder.Folder) (file.File, error),
+) *sharedDownload {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	download := &sharedDownload{done: make(chan struct{}), cancel: cancel}
+	s.downloads[key] = download
+
+	s.wg.Go(func() {
+		defer close(download.done)
+		defer cancel()
+
+		dir, err := data.CreateUniqueDirIn()
+		if err != nil {
+			download.err = fmt.Errorf("creating random dir: %w", err)
+		} else {
+			download.destination, download.err = fetch(ctx, dir)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if download.err != nil {
+			if s.downloads[key] == download {
+				delete(s.downloads, key)
+			}
+
+			if dir.IsSet() {
+				download.err = errors.Join(download.err, dir.Remove())
+			}
+
+			return
+		}
+
+		s.dirs = append(s.dirs, dir)
+	})
+
+	return download
+}
+
+// leave stops waiting for the download with the key, cancelling it if no other tool waits for it.
+// A cancelled download is forgotten right away, such that tools coming later try again.
+func (s *Shared) leave(key string, download *sharedDownload) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	download.waiters--
+
+	if download.waiters > 0 {
+		return
+	}
+
+	select {
+	case <-download.done:
+		return
+	default:
+	}
+
+	download.cancel()
+
+	if s.downloads[key] == download {
+		delete(s.downloads, key)
+	}
+}
+
+// Remove removes the directories of all completed downloads, after waiting for those in progress.
+func (s *Shared) Remove() error {
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+
+	for _, dir := range s.dirs {
+		errs = append(errs, dir.Remove())
+	}
+
+	s.dirs = nil
+	s.downloads = make(map[string]*sharedDownload)
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,260 @@
+package install_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/tools/sources/install"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+func TestSharedDownload(t *testing.T) {
+	t.Parallel()
+
+	shared := install.NewShared()
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+
+	fetch := func(_ context.Context, dir folder.Folder) (file.File, error) {
+		calls.Add(1)
+
+		<-release
+
+		destination := dir.WithFile("tool")
+
+		return destination, destination.Write([]byte("tool"), 0o600)
+	}
+
+	const tools = 5
+
+	destinations := make([]file.File, tools)
+
+	var wg sync.WaitGroup
+
+	for i := range tools {
+		wg.Go(func() {
+			destination, err := shared.Download(t.Context(), "https://example.com/tool.tar.gz", fetch)
+			if err != nil {
+				t.Errorf("Download() error = %v", err)
+			}
+
+			destinations[i] = destination
+		})
+	}
+
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Download() fetched %d times, want 1", got)
+	}
+
+	for _, destination := range destinations {
+		if destination != destinations[0] || !destination.Exists() {
+			t.Errorf("Download() = %q, want existing %q", destination, destinations[0])
+		}
+	}
+
+	if _, err := shared.Download(t.Context(), "https://example.com/tool.tar.gz", fetch); err != nil {
+		t.Errorf("Download() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Download() after completion fetched %d times, want 1", got)
+	}
+
+	if err := shared.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	if destinations[0].Exists() {
+		t.Errorf("Remove() left %q behind", destinations[0])
+	}
+}
+
+func TestSharedDownloadFailure(t *testing.T) {
+	t.Parallel()
+
+	shared := install.NewShared()
+
+	t.Cleanup(func() {
+		if err := shared.Remove(); err != nil {
+			t.Errorf("Remove() error = %v", err)
+		}
+	})
+
+	errFetch := errors.New("bad response code: 503")
+
+	var calls atomic.Int32
+
+	fetch := func(_ context.Context, dir folder.Folder) (file.File, error) {
+		if calls.Add(1) == 1 {
+			return "", errFetch
+		}
+
+		destination := dir.WithFile("tool")
+
+		return destination, destination.Write([]byte("tool"), 0o600)
+	}
+
+	if _, err := shared.Download(t.Context(), "key", fetch); !errors.Is(err, errFetch) {
+		t.Fatalf("Download() error = %v, want %v", err, errFetch)
+	}
+
+	if _, err := shared.Download(t.Context(), "key", fetch); err != nil {
+		t.Fatalf("Download() after failure error = %v", err)
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Download() fetched %d times, want 2", got)
+	}
+}
+
+// waitingContext reports when Download starts waiting on it.
+type waitingContext struct {
+	context.Context
+
+	once    sync.Once
+	waiting chan struct{}
+}
+
+func (c *waitingContext) Done() <-chan struct{} {
+	c.once.Do(func() { close(c.waiting) })
+
+	return c.Context.Done()
+}
+
+func TestSharedDownloadCancelled(t *testing.T) {
+	t.Parallel()
+
+	shared := install.NewShared()
+
+	t.Cleanup(func() {
+		if err := shared.Remove(); err != nil {
+			t.Errorf("Remove() error = %v", err)
+		}
+	})
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+
+	var calls atomic.Int32
+
+	fetch := func(ctx context.Context, dir folder.Folder) (file.File, error) {
+		calls.Add(1)
+		started <- struct{}{}
+
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+
+		destination := dir.WithFile("tool")
+
+		return destination, destination.Write([]byte("tool"), 0o600)
+	}
+
+	first, cancel := context.WithCancel(t.Context())
+
+	firstErr := make(chan error, 1)
+
+	go func() {
+		_, err := shared.Download(first, "key", fetch)
+		firstErr <- err
+	}()
+
+	<-started
+
+	waiter := &waitingContext{Context: t.Context(), waiting: make(chan struct{})}
+	waiterErr := make(chan error, 1)
+
+	var destination file.File
+
+	go func() {
+		var err error
+
+		destination, err = shared.Download(waiter, "key", fetch)
+		waiterErr <- err
+	}()
+
+	<-waiter.waiting
+	cancel()
+
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Download() of cancelled tool error = %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+
+	if err := <-waiterErr; err != nil {
+		t.Fatalf("Download() of waiting tool error = %v", err)
+	}
+
+	if !destination.Exists() {
+		t.Errorf("Download() = %q, which does not exist", destination)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Download() fetched %d times, want 1", got)
+	}
+}
+
+func TestSharedDownloadAbandoned(t *testing.T) {
+	t.Parallel()
+
+	shared := install.NewShared()
+
+	var calls atomic.Int32
+
+	started := make(chan struct{}, 2)
+
+	fetch := func(ctx context.Context, dir folder.Folder) (file.File, error) {
+		if calls.Add(1) == 1 {
+			started <- struct{}{}
+
+			<-ctx.Done()
+
+			return "", ctx.Err()
+		}
+
+		destination := dir.WithFile("tool")
+
+		return destination, destination.Write([]byte("tool"), 0o600)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := shared.Download(ctx, "key", fetch)
+		done <- err
+	}()
+
+	<-started
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Download() error = %v, want %v", err, context.Canceled)
+	}
+
+	if _, err := shared.Download(t.Context(), "key", fetch); err != nil {
+		t.Errorf("Download() after abandoned download error = %v", err)
+	}
+
+	// Remove waits for the abandoned download to stop.
+	if err := shared.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Download() fetched %d times, want 2", got)
+	}
+}
@@ -2,6 +2,8 @@
 package none
 
 import (
+	"context"
+
 	"github.com/hashicorp/go-getter/v2"
 
 	"github.com/idelchi/godyl/internal/match"
@@ -24,17 +26,17 @@ func (n *None) Initialize(_ string) error {
 }
 
 // Version is a no-op implementation of the Populator interface.
-func (n *None) Version(_ string) error {
+func (n *None) Version(_ context.Context, _ string) error {
 	return nil
 }
 
 // URL is a no-op implementation of the Populator interface.
-func (n *None) URL(_ string, _ []string, _ string, _ match.Requirements) error {
+func (n *None) URL(_ context.Context, _ string, _ []string, _ string, _ match.Requirements) error {
 	return nil
 }
 
 // Install is a no-op implementation of the Populator interface.
 // Returns empty values as no actual installation is performed.
-func (n *None) Install(_ install.Data, _ getter.ProgressTracker) (string, file.File, error) {
+func (n *None) Install(_ context.Context, _ install.Data, _ getter.ProgressTracker) (string, file.File, error) {
 	return "", file.File(""), nil
 }
@@ -5,6 +5,7 @@
 package sources
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/go-getter/v2"
@@ -16,6 +17,7 @@ import (
 	"github.com/idelchi/godyl/internal/tools/sources/install"
 	"github.com/idelchi/godyl/internal/tools/sources/none"
 	"github.com/idelchi/godyl/internal/tools/sources/url"
+	"github.com/idelchi/godyl/pkg/credentials"
 	"github.com/idelchi/godyl/pkg/path/file"
 )
 
@@ -67,6 +69,10 @@ type Source struct {
 	Go     goc.Go
 	Type   Type `validate:"oneof=github gitlab url none go"`
 	GitLab gitlab.GitLab
+	// Tokens are looked up by host and take precedence over the token of the source.
+	Tokens credentials.Tokens
+	// Chain lists the credential helpers to query for a token for a self-hosted server.
+	Chain []string
 }
 
 // Populator defines the interface that all source types must implement.
@@ -74,15 +80,25 @@ type Source struct {
 // from initialization through execution, versioning, path setup, and installation.
 type Populator interface {
 	Initialize(repo string) error
-	Version(version string) error
-	URL(name string, extensions []string, version string, requirements match.Requirements) error
-	Install(data install.Data, progressListener getter.ProgressTracker) (string, file.File, error)
+	Version(ctx context.Context, version string) error
+	URL(ctx context.Context, name string, extensions []string, version string, requirements match.Requirements) error
+	Install(
+		ctx context.Context,
+		data install.Data,
+		progressListener getter.ProgressTracker,
+	) (string, file.File, error)
 	Get(key string) string
 }
 
 // Installer returns the appropriate Populator implementation for the source Type.
 // Returns an error if the source type is unknown or unsupported.
 func (s *Source) Installer() (Populator, error) {
+	s.GitHub.SetTokens(s.Tokens)
+	s.GitLab.SetTokens(s.Tokens)
+	s.URL.SetTokens(s.Tokens)
+	s.GitHub.SetChain(s.Chain)
+	s.GitLab.SetChain(s.Chain)
+
 	switch s.Type {
 	case GITHUB:
 		return &s.GitHub, nil
@@ -5,12 +5,14 @@
 package url
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/hashicorp/go-getter/v2"
 
 	"github.com/idelchi/godyl/internal/match"
 	"github.com/idelchi/godyl/internal/tools/sources/install"
+	"github.com/idelchi/godyl/pkg/credentials"
 	"github.com/idelchi/godyl/pkg/path/file"
 )
 
@@ -19,6 +21,7 @@ type URL struct {
 	Headers http.Header      `mapstructure:"headers" yaml:"headers"`
 	Data    install.Metadata `mapstructure:"-"       yaml:"-"`
 	Token   string           `mapstructure:"token"   mask:"fixed"   yaml:"token"`
+	tokens  credentials.Tokens
 }
 
 // Initialize is a no-op implementation of the Populator interface.
@@ -27,13 +30,13 @@ func (u *URL) Initialize(_ string) error {
 }
 
 // Version is a no-op implementation of the Populator interface.
-func (u *URL) Version(_ string) error {
+func (u *URL) Version(_ context.Context, _ string) error {
 	return nil
 }
 
 // URL stores the provided URL in the metadata.
 // The URL will be used as the download source during installation.
-func (u *URL) URL(name string, _ []string, _ string, _ match.Requirements) error {
+func (u *URL) URL(_ context.Context, name string, _ []string, _ string, _ match.Requirements) error {
 	u.Data.Set("url", name)
 
 	return nil
@@ -43,14 +46,16 @@ func (u *URL) URL(name string, _ []string, _ string, _ match.Requirements) error
 // Handles authentication, downloads the file, and processes it according to InstallData.
 // Returns the operation output, downloaded file information, and any errors.
 func (u *URL) Install(
+	ctx context.Context,
 	d install.Data,
 	progressListener getter.ProgressTracker,
 ) (output string, found file.File, err error) {
 	d.Header = u.Headers
+	d.Tokens = u.tokens
 	// Pass the progress listener down
 	d.ProgressListener = progressListener
 
-	found, err = install.Download(d)
+	found, err = install.Download(ctx, d)
 
 	return "", found, err
 }
@@ -59,3 +64,8 @@ func (u *URL) Install(
 func (u *URL) Get(attribute string) string {
 	return u.Data.Get(attribute)
 }
+
+// SetTokens sets the per-host tokens to look up the token for the download URL with.
+func (u *URL) SetTokens(tokens credentials.Tokens) {
+	u.tokens = tokens
+}
@@ -0,0 +1,34 @@
+package tool
+
+import (
+	"context"
+
+	"github.com/idelchi/godyl/internal/tools/sources"
+	"github.com/idelchi/godyl/internal/tools/sources/install"
+)
+
+// InstallStatic exports the unexported installStatic for use in tests.
+func (t *Tool) InstallStatic(
+	ctx context.Context,
+	populator sources.Populator,
+	data install.Data,
+	glibcErr error,
+) (string, error) {
+	return t.installStatic(ctx, populator, data, nil, glibcErr)
+}
+
+// SetSelected marks the URL and the checksum of the tool as selected from the release assets for testing.
+func SetSelected(t *Tool, asset, checksum bool) {
+	t.assetSelected = asset
+	t.checksumSelected = checksum
+}
+
+// WithLimits exports the unexported withLimits for use in tests.
+func (t *Tool) WithLimits(ctx context.Context) (context.Context, context.CancelFunc) {
+	return t.withLimits(ctx)
+}
+
+// TimedOut exports the unexported timedOut for use in tests.
+func (t *Tool) TimedOut(ctx context.Context, err error) error {
+	return t.timedOut(ctx, err)
+}
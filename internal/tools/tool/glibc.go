@@ -0,0 +1,90 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/go-getter/v2"
+
+	"github.com/idelchi/godyl/internal/match"
+	"github.com/idelchi/godyl/internal/tools/checksum"
+	"github.com/idelchi/godyl/internal/tools/hints"
+	"github.com/idelchi/godyl/internal/tools/sources"
+	"github.com/idelchi/godyl/internal/tools/sources/install"
+)
+
+// staticHint requires the assets to be built against musl or statically linked.
+const staticHint = "musl|static"
+
+// installStatic installs a musl or statically linked asset from the same release instead of the selected one,
+// which requires a newer glibc than the one of the host, as reported by glibcErr.
+// It fails with glibcErr if the asset was not selected from the release assets, or there is no such asset.
+func (t *Tool) installStatic(
+	ctx context.Context,
+	populator sources.Populator,
+	data install.Data,
+	progressListener getter.ProgressTracker,
+	glibcErr error,
+) (string, error) {
+	if !t.assetSelected {
+		return "", fmt.Errorf("%w: the url is configured, no other asset can be selected", glibcErr)
+	}
+
+	if !t.checksumSelected && t.Checksum.Type != checksum.None {
+		return "", fmt.Errorf("%w: the checksum is configured for %q, no other asset can be selected", glibcErr, t.URL)
+	}
+
+	platform := t.Platform
+	platform.Library.Name = "musl"
+
+	if err := platform.Library.Parse(); err != nil {
+		return "", fmt.Errorf("%w: parsing library: %w", glibcErr, err)
+	}
+
+	hint := hints.Hint{Pattern: staticHint, Type: hints.Regex}
+	hint.Match.Set(string(hints.Required))
+
+	if err := hint.Parse(); err != nil {
+		return "", fmt.Errorf("%w: parsing hint: %w", glibcErr, err)
+	}
+
+	if err := populator.URL(ctx, t.Name, nil, t.Version.Version, match.Requirements{
+		Platform: platform,
+		Hints:    append(slices.Clone(*t.Hints.Reduced()), hint),
+		Checksum: t.Checksum.Pattern,
+	}); err != nil {
+		return "", fmt.Errorf("%w: no musl or static asset available: %w", glibcErr, err)
+	}
+
+	url := populator.Get("url")
+	if url == t.URL {
+		return "", fmt.Errorf("%w: no musl or static asset available", glibcErr)
+	}
+
+	sum := t.Checksum
+
+	if sum.Type != checksum.None {
+		sum = checksum.Checksum{Type: checksum.File, Pattern: t.Checksum.Pattern, Value: populator.Get("checksum")}
+
+		if err := sum.Resolve(ctx, t.NoVerifySSL); err != nil {
+			return "", fmt.Errorf("%w: resolving checksum of %q: %w", glibcErr, url, err)
+		}
+
+		if !sum.IsSet() && sum.IsMandatory() {
+			return "", fmt.Errorf("%w: no checksum could be determined for %q", glibcErr, url)
+		}
+	}
+
+	data.Path = url
+	data.Checksum = sum
+
+	output, _, err := populator.Install(ctx, data, progressListener)
+	if err != nil {
+		return output, err
+	}
+
+	t.URL, t.Checksum = url, sum
+
+	return output, nil
+}
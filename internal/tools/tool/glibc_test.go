@@ -0,0 +1,163 @@
+package tool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/go-getter/v2"
+
+	"github.com/idelchi/godyl/internal/match"
+	"github.com/idelchi/godyl/internal/tools/checksum"
+	"github.com/idelchi/godyl/internal/tools/hints"
+	"github.com/idelchi/godyl/internal/tools/sources/install"
+	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+const (
+	glibcAsset = "https://example.com/tool_linux_amd64.tar.gz"
+	muslAsset  = "https://example.com/tool_linux_amd64_musl.tar.gz"
+)
+
+// populator selects the musl asset of a release, if it has one, and records the installation.
+type populator struct {
+	values       map[string]string
+	requirements match.Requirements
+	installed    *install.Data
+}
+
+func (p *populator) Initialize(string) error { return nil }
+
+func (p *populator) Version(context.Context, string) error { return nil }
+
+func (p *populator) URL(_ context.Context, _ string, _ []string, _ string, requirements match.Requirements) error {
+	p.requirements = requirements
+
+	if p.values == nil {
+		return errors.New("no matching asset found")
+	}
+
+	return nil
+}
+
+func (p *populator) Install(_ context.Context, data install.Data, _ getter.ProgressTracker) (string, file.File, error) {
+	p.installed = &data
+
+	return "installed", "", nil
+}
+
+func (p *populator) Get(key string) string { return p.values[key] }
+
+func TestInstallStatic(t *testing.T) {
+	t.Parallel()
+
+	errGlibc := fmt.Errorf("%w: requires glibc 2.39", install.ErrGlibc)
+
+	digest := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	selected := checksum.Checksum{Type: checksum.SHA256, Value: "fedcba"}
+
+	tests := []struct {
+		name          string
+		urlSelected   bool
+		checkSelected bool
+		checksum      checksum.Checksum
+		values        map[string]string
+		wantChecksum  checksum.Checksum
+		wantErr       bool
+	}{
+		{
+			name:          "configured url refused",
+			checkSelected: true,
+			checksum:      selected,
+			values:        map[string]string{"url": muslAsset},
+			wantErr:       true,
+		},
+		{
+			name:        "configured checksum refused",
+			urlSelected: true,
+			checksum:    selected,
+			values:      map[string]string{"url": muslAsset},
+			wantErr:     true,
+		},
+		{
+			name:          "no musl asset found",
+			urlSelected:   true,
+			checkSelected: true,
+			checksum:      selected,
+			wantErr:       true,
+		},
+		{
+			name:          "same asset selected again",
+			urlSelected:   true,
+			checkSelected: true,
+			checksum:      selected,
+			values:        map[string]string{"url": glibcAsset},
+			wantErr:       true,
+		},
+		{
+			name:          "musl checksum re-resolved",
+			urlSelected:   true,
+			checkSelected: true,
+			checksum:      selected,
+			values:        map[string]string{"url": muslAsset, "checksum": "sha256:" + digest},
+			wantChecksum:  checksum.Checksum{Type: checksum.SHA256, Value: digest},
+		},
+		{
+			name:          "disabled checksum kept",
+			urlSelected:   true,
+			checkSelected: true,
+			checksum:      checksum.Checksum{Type: checksum.None},
+			values:        map[string]string{"url": muslAsset, "checksum": "sha256:" + digest},
+			wantChecksum:  checksum.Checksum{Type: checksum.None},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tl := &tool.Tool{Name: "tool", URL: glibcAsset, Checksum: tt.checksum, Hints: &hints.Hints{}}
+			tool.SetSelected(tl, tt.urlSelected, tt.checkSelected)
+
+			p := &populator{values: tt.values}
+
+			_, err := tl.InstallStatic(t.Context(), p, install.Data{Path: glibcAsset, Checksum: tt.checksum}, errGlibc)
+
+			if tt.wantErr {
+				if !errors.Is(err, install.ErrGlibc) {
+					t.Errorf("InstallStatic() error = %v, want %v", err, install.ErrGlibc)
+				}
+
+				if p.installed != nil {
+					t.Errorf("InstallStatic() installed %q", p.installed.Path)
+				}
+
+				if tl.URL != glibcAsset || tl.Checksum != tt.checksum {
+					t.Errorf("InstallStatic() changed the tool to %q %+v", tl.URL, tl.Checksum)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("InstallStatic() error = %v", err)
+			}
+
+			if p.installed == nil || p.installed.Path != muslAsset || p.installed.Checksum != tt.wantChecksum {
+				t.Fatalf("InstallStatic() installed %+v, want %q with %+v", p.installed, muslAsset, tt.wantChecksum)
+			}
+
+			if tl.URL != muslAsset || tl.Checksum != tt.wantChecksum {
+				t.Errorf("InstallStatic() tool = %q %+v, want %q %+v", tl.URL, tl.Checksum, muslAsset, tt.wantChecksum)
+			}
+
+			if p.requirements.Platform.Library.Name != "musl" ||
+				!slices.ContainsFunc(p.requirements.Hints, func(h hints.Hint) bool { return h.Pattern == "musl|static" }) {
+				t.Errorf("InstallStatic() requirements = %+v, want musl or static assets", p.requirements)
+			}
+		})
+	}
+}
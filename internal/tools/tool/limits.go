@@ -0,0 +1,51 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/idelchi/godyl/internal/network"
+)
+
+// ErrTimeout is returned when an operation of the tool exceeds its timeout.
+var ErrTimeout = errors.New("timed out")
+
+// withLimits returns the context for an operation of the tool, limited by its timeout
+// and retrying its requests with its retry policy.
+func (t *Tool) withLimits(ctx context.Context) (context.Context, context.CancelFunc) {
+	policy := network.PolicyFrom(ctx)
+
+	retries, wait := policy.Retries, policy.WaitMin
+
+	if t.Retries != nil {
+		retries = *t.Retries
+	}
+
+	if t.RetryWait > 0 {
+		wait = t.RetryWait
+	}
+
+	ctx = network.WithPolicy(ctx, network.NewPolicy(retries, wait))
+
+	if t.Timeout > 0 {
+		return context.WithTimeout(ctx, t.Timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// timedOut returns the error of an operation whose context was limited by the timeout of the tool,
+// marking it as a timeout if the timeout was exceeded.
+func (t *Tool) timedOut(ctx context.Context, err error) error {
+	if !t.exceeded(ctx) {
+		return err
+	}
+
+	return errors.Join(fmt.Errorf("%w after %s", ErrTimeout, t.Timeout), err)
+}
+
+// exceeded reports whether the timeout of the tool was exceeded for the context limited by it.
+func (t *Tool) exceeded(ctx context.Context) bool {
+	return t.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
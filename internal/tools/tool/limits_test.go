@@ -0,0 +1,156 @@
+package tool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/idelchi/godyl/internal/network"
+	"github.com/idelchi/godyl/internal/tools/result"
+	"github.com/idelchi/godyl/internal/tools/tool"
+)
+
+func TestWithLimits(t *testing.T) {
+	t.Parallel()
+
+	zero, two := 0, 2
+
+	tests := []struct {
+		name         string
+		retries      *int
+		retryWait    time.Duration
+		timeout      time.Duration
+		wantRetries  int
+		wantWait     time.Duration
+		wantDeadline bool
+	}{
+		{
+			name:        "unset retries keep the global policy",
+			wantRetries: 5,
+			wantWait:    time.Second,
+		},
+		{
+			name:        "zero retries override the global policy",
+			retries:     &zero,
+			wantRetries: 0,
+			wantWait:    time.Second,
+		},
+		{
+			name:        "retries override the global policy",
+			retries:     &two,
+			wantRetries: 2,
+			wantWait:    time.Second,
+		},
+		{
+			name:        "retry wait overrides the global policy",
+			retryWait:   3 * time.Second,
+			wantRetries: 5,
+			wantWait:    3 * time.Second,
+		},
+		{
+			name:         "timeout sets a deadline",
+			timeout:      time.Minute,
+			wantRetries:  5,
+			wantWait:     time.Second,
+			wantDeadline: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tl := &tool.Tool{Retries: tt.retries, RetryWait: tt.retryWait, Timeout: tt.timeout}
+
+			ctx := network.WithPolicy(context.Background(), network.NewPolicy(5, time.Second))
+
+			limited, cancel := tl.WithLimits(ctx)
+			defer cancel()
+
+			policy := network.PolicyFrom(limited)
+
+			if policy.Retries != tt.wantRetries {
+				t.Errorf("retries = %d, want %d", policy.Retries, tt.wantRetries)
+			}
+
+			if policy.WaitMin != tt.wantWait {
+				t.Errorf("wait = %s, want %s", policy.WaitMin, tt.wantWait)
+			}
+
+			if _, ok := limited.Deadline(); ok != tt.wantDeadline {
+				t.Errorf("deadline set = %t, want %t", ok, tt.wantDeadline)
+			}
+		})
+	}
+}
+
+func TestTimedOut(t *testing.T) {
+	t.Parallel()
+
+	failure := errors.New("connection reset")
+
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		parent      func() (context.Context, context.CancelFunc)
+		wantTimeout bool
+	}{
+		{
+			name:        "exceeded timeout is a timeout",
+			timeout:     time.Millisecond,
+			parent:      func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			wantTimeout: true,
+		},
+		{
+			name:    "failure within the timeout is a plain failure",
+			timeout: time.Minute,
+			parent:  func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+		},
+		{
+			name:   "failure without a timeout is a plain failure",
+			parent: func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+		},
+		{
+			name: "deadline of the caller is not a timeout of the tool",
+			parent: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Millisecond)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tl := &tool.Tool{Timeout: tt.timeout}
+
+			parent, cancelParent := tt.parent()
+			defer cancelParent()
+
+			limited, cancel := tl.WithLimits(parent)
+			defer cancel()
+
+			if deadline, ok := limited.Deadline(); ok && time.Until(deadline) < time.Second {
+				<-limited.Done()
+			}
+
+			err := tl.TimedOut(limited, failure)
+
+			if !errors.Is(err, failure) {
+				t.Errorf("error %q does not wrap the failure", err)
+			}
+
+			if got := errors.Is(err, tool.ErrTimeout); got != tt.wantTimeout {
+				t.Errorf("errors.Is(%q, ErrTimeout) = %t, want %t", err, got, tt.wantTimeout)
+			}
+
+			// The processor reports failed results wrapping ErrTimeout as timed out.
+			res := result.WithFailed("resolving tool").Wrap(err)
+
+			if got := res.IsFailed() && errors.Is(res.AsError(), tool.ErrTimeout); got != tt.wantTimeout {
+				t.Errorf("result %q timed out = %t, want %t", res.AsError(), got, tt.wantTimeout)
+			}
+		})
+	}
+}
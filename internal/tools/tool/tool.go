@@ -5,12 +5,15 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/goccy/go-yaml/ast"
 
 	"github.com/idelchi/godyl/internal/cache"
 	"github.com/idelchi/godyl/internal/debug"
 	"github.com/idelchi/godyl/internal/detect"
+	"github.com/idelchi/godyl/internal/github"
+	"github.com/idelchi/godyl/internal/release"
 	"github.com/idelchi/godyl/internal/tools/checksum"
 	"github.com/idelchi/godyl/internal/tools/command"
 	"github.com/idelchi/godyl/internal/tools/exe"
@@ -20,13 +23,17 @@ import (
 	"github.com/idelchi/godyl/internal/tools/mode"
 	"github.com/idelchi/godyl/internal/tools/skip"
 	"github.com/idelchi/godyl/internal/tools/sources"
+	"github.com/idelchi/godyl/internal/tools/sources/install"
 	"github.com/idelchi/godyl/internal/tools/strategy"
 	"github.com/idelchi/godyl/internal/tools/tags"
 	"github.com/idelchi/godyl/internal/tools/values"
 	"github.com/idelchi/godyl/internal/tools/version"
+	"github.com/idelchi/godyl/pkg/download"
 	"github.com/idelchi/godyl/pkg/env"
 	"github.com/idelchi/godyl/pkg/executable"
+	"github.com/idelchi/godyl/pkg/generic"
 	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
 	"github.com/idelchi/godyl/pkg/unmarshal"
 )
 
@@ -78,10 +85,28 @@ type Tool struct {
 	Inherit *inherit.Inherit `json:"inherit" mapstructure:"inherit" yaml:"inherit"`
 	// Checksum defines the checksum configuration for verifying the integrity of the tool.
 	Checksum checksum.Checksum `json:"checksum" mapstructure:"checksum" yaml:"checksum"`
+	// Timeout limits the resolution, the download and the post-installation commands of the tool, each.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout" yaml:"timeout"`
+	// Retries is the number of retries of failed API requests, downloads and checksum fetches.
+	Retries *int `json:"retries" mapstructure:"retries" yaml:"retries"`
+	// RetryWait is the initial wait between retries, doubled for each retry.
+	RetryWait time.Duration `json:"retry-wait" mapstructure:"retry-wait" yaml:"retry-wait"`
 	// Cache can be carried around for various checks
 	cache *cache.Cache `json:"-"`
+	// archives serves and stores the downloads of the tool, if set
+	archives *download.ArchiveCache `json:"-"`
+	// partials keeps the partial downloads of the tool to resume, if set
+	partials folder.Folder `json:"-"`
+	// shared shares the downloads of the tool with other tools, if set
+	shared *install.Shared `json:"-"`
 	// populator stores the last successful populator
 	populator sources.Populator `json:"-"`
+	// selected marks that the tool passed its tags and skip conditions during resolution
+	selected bool `json:"-"`
+	// assetSelected marks that the URL was selected from the release assets during resolution
+	assetSelected bool `json:"-"`
+	// checksumSelected marks that the checksum was taken from the source during resolution
+	checksumSelected bool `json:"-"`
 }
 
 // NewEmptyTool returns an empty tool to make sure that no pointers are nil.
@@ -110,18 +135,56 @@ func (t *Tool) DisableCache() {
 	t.cache = nil
 }
 
+// EnableArchiveCache sets the archive cache to serve and store the downloads of the Tool instance with.
+func (t *Tool) EnableArchiveCache(archives *download.ArchiveCache) {
+	t.archives = archives
+}
+
+// EnableResume sets the folder to keep partial downloads of the Tool instance in, such that they can be resumed.
+func (t *Tool) EnableResume(partials folder.Folder) {
+	t.partials = partials
+}
+
+// EnableSharedDownloads sets the downloads to share with other tools, such that identical files are downloaded once.
+func (t *Tool) EnableSharedDownloads(shared *install.Shared) {
+	t.shared = shared
+}
+
+// GitHubRepository returns the repository and token to look up the latest release of the Tool instance with
+// ahead of its resolution, if it resolves the latest release from GitHub.
+func (t *Tool) GitHubRepository() (github.Name, string, bool) {
+	if t.Source.Type != sources.GITHUB || !generic.IsZero(t.Version.Version) {
+		return github.Name{}, "", false
+	}
+
+	t.Source.GitHub.SetTokens(t.Source.Tokens)
+	t.Source.GitHub.SetChain(t.Source.Chain)
+
+	return t.Source.GitHub.Batchable(t.Name)
+}
+
+// UseLatestRelease sets the latest release of the Tool instance looked up ahead of its resolution.
+func (t *Tool) UseLatestRelease(release *release.Release) {
+	t.Source.GitHub.UseLatestRelease(release)
+}
+
 // Exists checks if the tool's executable exists in the configured output path.
 // Returns true if the file exists and is a regular file.
 func (t Tool) Exists() bool {
+	f := t.Executable()
+
+	return f.Exists() && f.IsFile()
+}
+
+// Executable returns the path of the tool's executable in the configured output path.
+func (t Tool) Executable() file.File {
 	name := t.Exe.Name
 	// Append platform-specific file extension to the executable name.
 	if !strings.HasSuffix(t.Exe.Name, t.Platform.Extension.String()) && !file.File(t.Exe.Name).HasExtension() {
 		name += t.Platform.Extension.String()
 	}
 
-	f := file.New(t.Output, name)
-
-	return f.Exists() && f.IsFile()
+	return file.New(t.Output, name)
 }
 
 // GetCurrentVersion attempts to retrieve the current version of the tool.
@@ -134,8 +197,8 @@ func (t Tool) GetCurrentVersion() string {
 	exe := executable.New(t.Output, t.Exe.Name)
 
 	// Try to get version - first from cache, then using commands
-	if !t.NoCache {
-		if item, err := t.cache.Get(t.ID()); err == nil {
+	if !t.NoCache && t.cache != nil {
+		if item, err := t.cache.Get(t.ID()); err == nil && item[0].Version.Version != "" {
 			return item[0].Version.Version
 		}
 	}
@@ -165,6 +228,11 @@ func (t Tool) GetCurrentVersion() string {
 	return parsed
 }
 
+// Selected reports whether the tool passed its tags and skip conditions during its resolution.
+func (t Tool) Selected() bool {
+	return t.selected
+}
+
 // GetStrategy returns the tool's strategy.
 func (t Tool) GetStrategy() strategy.Strategy {
 	return t.Strategy
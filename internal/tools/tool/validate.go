@@ -2,6 +2,7 @@ package tool
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/idelchi/godyl/internal/tools/sources/install"
 	"github.com/idelchi/godyl/internal/tools/strategy"
 	"github.com/idelchi/godyl/internal/tools/tags"
+	"github.com/idelchi/godyl/pkg/download/progress"
 	"github.com/idelchi/godyl/pkg/env"
 	"github.com/idelchi/godyl/pkg/generic"
 	"github.com/idelchi/godyl/pkg/path/file"
@@ -25,7 +27,7 @@ import (
 // Resolve attempts to resolve the tool's source and strategy based on the provided tags.
 // It handles environment variables, fallbacks, templating, and validation of the tool's
 // configuration. Returns a Result indicating success or failure with detailed messages.
-func (t *Tool) Resolve(tags tags.IncludeTags, options ...ResolveOption) result.Result {
+func (t *Tool) Resolve(ctx context.Context, tags tags.IncludeTags, options ...ResolveOption) result.Result {
 	// Initialize default options
 	opts := resolveOptions{}
 
@@ -99,14 +101,36 @@ func (t *Tool) Resolve(tags tags.IncludeTags, options ...ResolveOption) result.R
 			return res
 		}
 
+		t.selected = true
+
 		if opts.skipVersion {
 			return result.WithSkipped("skipped version resolution")
 		}
 
-		if res = t.resolve(populator, tmpl, opts); res.IsFailed() {
+		limited, cancel := t.withLimits(ctx)
+
+		progress.Enter(limited, progress.Resolving)
+
+		res = t.resolve(limited, populator, tmpl, opts)
+
+		if res.IsFailed() {
+			// Mark the failure as timeout if the timeout was exceeded, keeping its error.
+			if t.exceeded(limited) {
+				res = res.Wrap(t.timedOut(limited, res.Unwrap()))
+			}
+
+			cancel()
+
+			// Don't try the remaining fallbacks once interrupted.
+			if ctx.Err() != nil {
+				return res
+			}
+
 			continue // Move on to the next fallback.
 		}
 
+		cancel()
+
 		return res
 	}
 
@@ -118,16 +142,21 @@ func (t *Tool) Resolve(tags tags.IncludeTags, options ...ResolveOption) result.R
 // populator and template engine to achieve this. Returns a Result indicating success or failure.
 //
 //nolint:gocognit	// Acceptable complexity for this function
-func (t *Tool) resolve(populator sources.Populator, tmpl *templates.Processor, opts resolveOptions) result.Result {
+func (t *Tool) resolve(
+	ctx context.Context,
+	populator sources.Populator,
+	tmpl *templates.Processor,
+	opts resolveOptions,
+) result.Result {
 	// Retrieve the tool's version from the installer if it is not already set.
 	if generic.IsZero(t.Version.Version) {
-		if err := populator.Version(t.Name); err != nil {
+		if err := populator.Version(ctx, t.Name); err != nil {
 			return result.WithFailed(fmt.Sprintf("getting version: %s", err))
 		}
 
 		t.Version.Version = populator.Get("version")
 	} else if strings.Contains(t.Version.Version, "*") {
-		if err := populator.Version(t.Version.Version); err != nil {
+		if err := populator.Version(ctx, t.Version.Version); err != nil {
 			return result.WithFailed(fmt.Sprintf("getting version for pattern %q: %s", t.Version.Version, err))
 		}
 
@@ -166,7 +195,7 @@ func (t *Tool) resolve(populator sources.Populator, tmpl *templates.Processor, o
 			return result.WithFailed(fmt.Sprintf("parsing hints: %s", err))
 		}
 
-		if err := populator.URL(t.Name, nil, t.Version.Version, match.Requirements{
+		if err := populator.URL(ctx, t.Name, nil, t.Version.Version, match.Requirements{
 			Platform: t.Platform,
 			Hints:    *t.Hints.Reduced(),
 			Checksum: t.Checksum.Pattern,
@@ -175,6 +204,7 @@ func (t *Tool) resolve(populator sources.Populator, tmpl *templates.Processor, o
 		}
 
 		t.URL = populator.Get("url")
+		t.assetSelected = true
 	}
 
 	if t.NoVerifyChecksum || !t.Source.Type.SupportsChecksum() {
@@ -193,9 +223,10 @@ func (t *Tool) resolve(populator sources.Populator, tmpl *templates.Processor, o
 
 	if t.Checksum.Value == "" {
 		t.Checksum.Value = populator.Get("checksum")
+		t.checksumSelected = true
 	}
 
-	if err := t.Checksum.Resolve(t.NoVerifySSL); err != nil {
+	if err := t.Checksum.Resolve(ctx, t.NoVerifySSL); err != nil {
 		return result.WithFailed(fmt.Sprintf("resolving checksum: %s", err))
 	}
 
@@ -259,7 +290,7 @@ func (t *Tool) CheckSkipConditions(tags tags.IncludeTags) result.Result {
 // Download retrieves and installs the tool using its configured source and installer.
 // It handles progress tracking and executes any post-installation commands.
 // Returns a Result indicating success or failure with detailed messages.
-func (t *Tool) Download(_ context.Context, progressListener getter.ProgressTracker) result.Result {
+func (t *Tool) Download(ctx context.Context, progressListener getter.ProgressTracker) result.Result {
 	installer, err := t.Source.Installer()
 	if err != nil {
 		return result.WithFailed("getting installer").Wrap(err)
@@ -280,25 +311,46 @@ func (t *Tool) Download(_ context.Context, progressListener getter.ProgressTrack
 		Checksum:         t.Checksum,
 		NoVerifySSL:      t.NoVerifySSL,
 		NoVerifyChecksum: t.NoVerifyChecksum,
+		Archives:         t.archives,
+		Partials:         t.partials,
+		Shared:           t.shared,
+		Timeout:          t.Timeout,
 		// TODO(Idelchi): Pass OS and Architecture as they are and let downstream decide if they want Type(), or
 		// String(), or whatever.
-		OS:   t.Platform.OS.Type(),
-		Arch: t.Platform.Architecture.Type(),
+		OS:    t.Platform.OS.Type(),
+		Arch:  t.Platform.Architecture.Type(),
+		Glibc: t.Platform.Library.Version(),
 	}
 
 	// Pass the progress listener to the specific source's Install method
-	output, _, err := installer.Install(data, progressListener)
+	limited, cancel := t.withLimits(ctx)
+	defer cancel()
+
+	output, _, err := installer.Install(limited, data, progressListener)
+
+	message := "installed successfully"
+
+	// Fall back to a musl or statically linked asset of the release, for builds requiring a newer glibc
+	if errors.Is(err, install.ErrGlibc) {
+		output, err = t.installStatic(limited, installer, data, progressListener, err)
+		message = fmt.Sprintf("installed %q, as the selected asset requires a newer glibc", file.File(t.URL).Unescape().Base())
+	}
+
 	if err != nil {
-		return result.WithFailed("installing tool").Wrap(err).Wrapped(output)
+		return result.WithFailed("installing tool").Wrap(t.timedOut(limited, err)).Wrapped(output)
 	}
 
-	// Execute post-installation commands if any exist
+	// Execute post-installation commands if any exist, with a timeout of their own
 	if len(t.Commands.Commands) > 0 {
-		//nolint:contextcheck 	// TODO(Idelchi): Address this later
-		if output, err := t.Commands.Run(context.Background(), t.Env); err != nil {
-			return result.WithFailed("executing post-installation commands").Wrap(err).Wrapped(output)
+		limited, cancel := t.withLimits(ctx)
+		defer cancel()
+
+		progress.Enter(limited, progress.RunningCommands)
+
+		if output, err := t.Commands.Run(limited, t.Env); err != nil {
+			return result.WithFailed("executing post-installation commands").Wrap(t.timedOut(limited, err)).Wrapped(output)
 		}
 	}
 
-	return result.WithOK("installed successfully")
+	return result.WithOK(message)
 }
@@ -0,0 +1,199 @@
+// Package verify verifies installed tools against the checksums published for them.
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/idelchi/godyl/internal/cache"
+	"github.com/idelchi/godyl/internal/tools/checksum"
+	"github.com/idelchi/godyl/internal/tools/strategy"
+	"github.com/idelchi/godyl/internal/tools/tags"
+	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/pkg/download"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+// Outcome is the outcome of the verification of a tool.
+type Outcome string
+
+const (
+	// Pass is a tool matching the checksum published for it.
+	Pass Outcome = "pass"
+	// Fail is a tool not matching the checksum published for it, or changed since installed.
+	Fail Outcome = "fail"
+	// Unverifiable is a tool that cannot be verified, such as for lack of a published checksum.
+	Unverifiable Outcome = "unverifiable"
+)
+
+// Outcomes lists all outcomes, from the most to the least severe.
+var Outcomes = []Outcome{Fail, Unverifiable, Pass}
+
+// Report is the outcome of the verification of a tool.
+type Report struct {
+	// Tool is the name of the tool.
+	Tool string `json:"tool"`
+	// Path is the path of the installed tool.
+	Path string `json:"path,omitempty"`
+	// Version is the installed version.
+	Version string `json:"version,omitempty"`
+	// URL is the URL of the release asset of the installed version.
+	URL string `json:"url,omitempty"`
+	// Verified is the file verified against the checksum, the installed tool or the cached archive.
+	Verified string `json:"verified,omitempty"`
+	// Algorithm is the algorithm of the published checksum.
+	Algorithm string `json:"algorithm,omitempty"`
+	// Checksum is the published checksum.
+	Checksum string `json:"checksum,omitempty"`
+	// Outcome is the outcome of the verification.
+	Outcome Outcome `json:"outcome"`
+	// Message describes the outcome.
+	Message string `json:"message"`
+}
+
+// Verifier verifies installed tools.
+type Verifier struct {
+	cache    *cache.Cache
+	archives *download.ArchiveCache
+}
+
+// New returns a verifier looking up the installed versions in the cache and the downloaded archives in archives.
+// Either may be nil, in which case the versions are parsed from the tools and archives cannot be verified.
+func New(cache *cache.Cache, archives *download.ArchiveCache) *Verifier {
+	return &Verifier{cache: cache, archives: archives}
+}
+
+// Verify re-resolves the release of the installed version of the tool and verifies the tool against its checksum.
+// It returns false for tools that are filtered out by their tags and skip conditions, or are not installed.
+func (v *Verifier) Verify(ctx context.Context, t *tool.Tool, tags tags.IncludeTags) (Report, bool) {
+	report := Report{Tool: t.Name}
+
+	// Resolve a copy up until the version, to find the installed tool and its version.
+	probe, err := t.Copied()
+	if err != nil {
+		return report.with(Unverifiable, err.Error()), true
+	}
+
+	probe.Strategy = strategy.Sync
+
+	res := probe.Resolve(ctx, tags, tool.WithoutVersion())
+
+	switch {
+	case res.IsFailed():
+		return report.with(Unverifiable, "resolving tool: "+res.Error()), true
+	case !probe.Selected(), !probe.Exists():
+		return report, false
+	}
+
+	report.Path = probe.Executable().Absolute().Path()
+
+	var item *cache.Item
+
+	if v.cache != nil {
+		if items, err := v.cache.Get(probe.ID()); err == nil {
+			item = items[0]
+			report.Version = item.Version.Version
+		}
+	}
+
+	if report.Version == "" {
+		report.Version = probe.GetCurrentVersion()
+	}
+
+	if report.Version == "" {
+		return report.with(Unverifiable, "installed version cannot be determined"), true
+	}
+
+	// Resolve the release of the installed version, regardless of the version installed.
+	t.Strategy = strategy.Force
+	t.Version.Version = report.Version
+
+	if res := t.Resolve(ctx, tags); !res.IsOK() {
+		return report.with(Unverifiable, "resolving release: "+res.Error()), true
+	}
+
+	report.URL = t.URL
+
+	asset := file.File(t.URL).Unescape().Base()
+
+	algorithm, expected, err := t.Checksum.Digest(ctx, asset, t.NoVerifySSL)
+	if err != nil {
+		return report.with(Unverifiable, err.Error()), true
+	}
+
+	report.Algorithm, report.Checksum = algorithm.String(), expected
+
+	installed := file.New(report.Path)
+
+	// The installed file must not have changed since installed, whatever is verified against the checksum.
+	if item != nil && item.Hash != "" {
+		if actual, err := sum(checksum.SHA256, installed); err != nil {
+			return report.with(Fail, err.Error()), true
+		} else if actual != item.Hash {
+			return report.with(Fail, "installed file changed since godyl installed it"), true
+		}
+	}
+
+	verified := installed
+
+	// Extracted tools can only be verified through the archive they were extracted from.
+	if download.IsArchive(asset) {
+		archive, ok := v.archive(t)
+		if !ok {
+			return report.with(Unverifiable, fmt.Sprintf("archive %q is not in the archive cache", asset)), true
+		}
+
+		verified = archive
+	}
+
+	report.Verified = verified.Path()
+
+	actual, err := sum(algorithm, verified)
+	if err != nil {
+		return report.with(Unverifiable, err.Error()), true
+	}
+
+	if actual != expected {
+		return report.with(Fail, fmt.Sprintf("%s checksum %s does not match published %s", algorithm, actual, expected)), true
+	}
+
+	if verified == installed {
+		return report.with(Pass, "installed file matches published checksum"), true
+	}
+
+	if item == nil || item.Hash == "" {
+		return report.with(Pass, "archive matches published checksum, installed file not tracked"), true
+	}
+
+	return report.with(Pass, "archive matches published checksum, installed file unchanged since extracted"), true
+}
+
+// archive returns the cached archive the tool was extracted from.
+func (v *Verifier) archive(t *tool.Tool) (file.File, bool) {
+	if v.archives == nil {
+		return file.New(), false
+	}
+
+	return v.archives.Lookup(t.URL, t.Checksum.ToQuery())
+}
+
+// with returns the report with the outcome and message set.
+func (r Report) with(outcome Outcome, message string) Report {
+	r.Outcome = outcome
+	r.Message = message
+
+	return r
+}
+
+// sum returns the checksum of the file.
+func sum(algorithm checksum.Type, f file.File) (string, error) {
+	r, err := f.Open()
+	if err != nil {
+		return "", err //nolint:wrapcheck	// Error does not need additional wrapping.
+	}
+
+	actual, err := algorithm.Sum(r)
+
+	return actual, errors.Join(err, r.Close())
+}
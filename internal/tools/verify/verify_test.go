@@ -0,0 +1,265 @@
+package verify_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/cache"
+	"github.com/idelchi/godyl/internal/tools/checksum"
+	"github.com/idelchi/godyl/internal/tools/hints"
+	"github.com/idelchi/godyl/internal/tools/sources"
+	"github.com/idelchi/godyl/internal/tools/tags"
+	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/internal/tools/verify"
+	"github.com/idelchi/godyl/pkg/download"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+// binary is the content of the installed tool.
+const binary = "#!/bin/sh\necho tool\n"
+
+// digest returns the hex encoded SHA-256 of the content.
+func digest(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// tarball returns a gzipped tarball containing the file with the name and content.
+func tarball(t *testing.T, name, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	archive := tarball(t, "tool", binary)
+
+	assets := map[string][]byte{
+		"/tool":        []byte(binary),
+		"/tool.tar.gz": archive,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := assets[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(srv.Close)
+
+	published := func(content []byte) checksum.Checksum {
+		return checksum.Checksum{Type: checksum.SHA256, Value: digest(content)}
+	}
+
+	tests := []struct {
+		name      string
+		asset     string
+		checksum  checksum.Checksum
+		installed bool
+		tracked   bool
+		hash      string
+		archived  bool
+		exclude   string
+		wantOK    bool
+		want      verify.Outcome
+		wantMsg   string
+	}{
+		{
+			name:     "not installed",
+			asset:    "/tool",
+			checksum: published([]byte(binary)),
+			tracked:  true,
+		},
+		{
+			name:      "filtered out by tags",
+			asset:     "/tool",
+			checksum:  published([]byte(binary)),
+			installed: true,
+			tracked:   true,
+			exclude:   "tool",
+		},
+		{
+			name:      "bare binary matches",
+			asset:     "/tool",
+			checksum:  published([]byte(binary)),
+			installed: true,
+			tracked:   true,
+			hash:      digest([]byte(binary)),
+			wantOK:    true,
+			want:      verify.Pass,
+			wantMsg:   "installed file matches published checksum",
+		},
+		{
+			name:      "bare binary does not match",
+			asset:     "/tool",
+			checksum:  published([]byte("other")),
+			installed: true,
+			tracked:   true,
+			wantOK:    true,
+			want:      verify.Fail,
+			wantMsg:   "does not match published",
+		},
+		{
+			name:      "installed file changed",
+			asset:     "/tool",
+			checksum:  published([]byte(binary)),
+			installed: true,
+			tracked:   true,
+			hash:      digest([]byte("original")),
+			wantOK:    true,
+			want:      verify.Fail,
+			wantMsg:   "installed file changed since godyl installed it",
+		},
+		{
+			name:      "archive matches",
+			asset:     "/tool.tar.gz",
+			checksum:  published(archive),
+			installed: true,
+			tracked:   true,
+			hash:      digest([]byte(binary)),
+			archived:  true,
+			wantOK:    true,
+			want:      verify.Pass,
+			wantMsg:   "archive matches published checksum, installed file unchanged since extracted",
+		},
+		{
+			name:      "archive missing from the archive cache",
+			asset:     "/tool.tar.gz",
+			checksum:  published(archive),
+			installed: true,
+			tracked:   true,
+			wantOK:    true,
+			want:      verify.Unverifiable,
+			wantMsg:   "is not in the archive cache",
+		},
+		{
+			name:      "no checksum published",
+			asset:     "/tool",
+			checksum:  checksum.Checksum{Type: checksum.None},
+			installed: true,
+			tracked:   true,
+			wantOK:    true,
+			want:      verify.Unverifiable,
+			wantMsg:   checksum.ErrNoChecksum.Error(),
+		},
+		{
+			name:      "installed version unknown",
+			asset:     "/tool",
+			checksum:  published([]byte(binary)),
+			installed: true,
+			wantOK:    true,
+			want:      verify.Unverifiable,
+			wantMsg:   "installed version cannot be determined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			output := folder.New(dir, "bin")
+			url := srv.URL + tt.asset
+
+			tl := &tool.Tool{
+				Name:     "tool",
+				URL:      url,
+				Output:   output.Path(),
+				Source:   sources.Source{Type: sources.URL},
+				Hints:    &hints.Hints{},
+				Checksum: tt.checksum,
+			}
+			tl.Exe.Name = "tool"
+
+			if err := tl.Platform.Detect(); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.installed {
+				if err := output.Create(); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := file.New(output.Path(), "tool").Write([]byte(binary), 0o700); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			installed := cache.New(file.New(dir, "cache.json"))
+
+			if tt.tracked {
+				item := &cache.Item{ID: tl.ID(), Name: tl.Name, Path: file.New(output.Path(), "tool").Path(), Hash: tt.hash}
+				item.Version.Version = "v1.0.0"
+
+				if err := installed.Add(item); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			archives := download.NewArchiveCache(folder.New(dir, "archives"), 0)
+
+			if tt.archived {
+				d := download.New(download.WithArchiveCache(archives), download.WithChecksum(tt.checksum.ToQuery()))
+
+				if _, err := d.Download(t.Context(), url, folder.New(dir, "extracted").Path()); err != nil {
+					t.Fatalf("populating the archive cache: %v", err)
+				}
+			}
+
+			filter := tags.IncludeTags{}
+			if tt.exclude != "" {
+				filter.Exclude = tags.Tags{tt.exclude}
+			}
+
+			report, ok := verify.New(installed, archives).Verify(t.Context(), tl, filter)
+
+			if ok != tt.wantOK {
+				t.Fatalf("Verify() = %+v, %t, want %t", report, ok, tt.wantOK)
+			}
+
+			if !ok {
+				return
+			}
+
+			if report.Outcome != tt.want || !strings.Contains(report.Message, tt.wantMsg) {
+				t.Errorf("Verify() = %s %q, want %s %q", report.Outcome, report.Message, tt.want, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package updater
+
+import (
+	"context"
+
+	"github.com/idelchi/godyl/internal/tools/tool"
+)
+
+// Verify exports the unexported verify for use in tests.
+func (u *Updater) Verify(ctx context.Context, t *tool.Tool) error {
+	return u.verify(ctx, t)
+}
+
+// VerifySignature exports the unexported verifySignature for use in tests.
+func (u *Updater) VerifySignature(ctx context.Context, t *tool.Tool) error {
+	return u.verifySignature(ctx, t)
+}
+
+// SetTarget sets the path of the binary to update for testing, instead of the running one.
+func SetTarget(u *Updater, path string) {
+	u.target = path
+}
+
+// HiddenBackupPath exports the unexported hiddenBackupPath for use in tests.
+var HiddenBackupPath = hiddenBackupPath //nolint:gochecknoglobals // standard export_test.go pattern
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/go-resty/resty/v2"
+
+	"github.com/idelchi/godyl/internal/network"
 )
 
 // Latest represents the latest version information including version and changelog.
@@ -17,7 +19,7 @@ type Latest struct {
 
 // Get reaches out to https://idelchi.github.io/godyl to check if there's a new version available.
 func (l *Latest) Get(pre bool) error {
-	client := resty.New()
+	client := resty.New().SetTransport(network.Retrying())
 
 	url := "https://idelchi.github.io/godyl/_versions/latest"
 
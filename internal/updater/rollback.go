@@ -0,0 +1,58 @@
+package updater
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+// backupPath returns the path the binary at exe is kept at when replaced by an update,
+// which is its path with the `.old` suffix.
+func backupPath(exe string) file.File {
+	return file.New(exe + ".old")
+}
+
+// executable returns the path of the binary to update, which is the running one unless set otherwise.
+func (u *Updater) executable() (string, error) {
+	if u.target != "" {
+		return u.target, nil
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("getting executable path: %w", err)
+	}
+
+	return exePath, nil
+}
+
+// Rollback restores the binary kept from before the last update,
+// keeping the running binary in its place, such that a second rollback undoes the first.
+func (u *Updater) Rollback() error {
+	exePath, err := u.executable()
+	if err != nil {
+		return err
+	}
+
+	backup := backupPath(exePath)
+
+	if !backup.Exists() {
+		return fmt.Errorf("no previous binary to roll back to: %q does not exist", backup)
+	}
+
+	// Read the backup up front, as it is replaced by the running binary
+	content, err := backup.Read()
+	if err != nil {
+		return fmt.Errorf("reading previous binary: %w", err)
+	}
+
+	if err := u.replaceBinary(bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("restoring previous binary: %w", err)
+	}
+
+	u.log.Infof("Rolled back to the previous binary, kept the replaced one as %q", backup)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package updater_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/updater"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		backup   bool
+		want     string
+		wantOld  string
+		wantText string
+	}{
+		{
+			name:    "swaps with godyl.old",
+			backup:  true,
+			want:    "previous",
+			wantOld: "current",
+		},
+		{
+			name:     "missing godyl.old",
+			want:     "current",
+			wantText: "no previous binary to roll back to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			binary := file.New(t.TempDir(), "godyl")
+			backup := file.New(binary.Path() + ".old")
+
+			if err := binary.Write([]byte("current"), 0o700); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.backup {
+				if err := backup.Write([]byte("previous"), 0o700); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			u := newUpdater(t, nil)
+			updater.SetTarget(u, binary.Path())
+
+			err := u.Rollback()
+			if (err != nil) != (tt.wantText != "") || (err != nil && !strings.Contains(err.Error(), tt.wantText)) {
+				t.Fatalf("Rollback() error = %v, want %q", err, tt.wantText)
+			}
+
+			if got, _ := binary.Read(); string(got) != tt.want {
+				t.Errorf("binary after Rollback() = %q, want %q", got, tt.want)
+			}
+
+			if got, _ := backup.Read(); string(got) != tt.wantOld {
+				t.Errorf("godyl.old after Rollback() = %q, want %q", got, tt.wantOld)
+			}
+		})
+	}
+}
+
+func TestHiddenBackupPath(t *testing.T) {
+	t.Parallel()
+
+	exe := file.New(t.TempDir(), "godyl.exe")
+
+	got := updater.HiddenBackupPath(exe.Path())
+
+	if want := file.New(exe.Dir(), ".godyl.exe.old"); got != want {
+		t.Errorf("HiddenBackupPath(%q) = %q, want %q", exe, got, want)
+	}
+
+	// The cleanup must never remove the binary kept for a rollback.
+	if got == file.New(exe.Path()+".old") {
+		t.Errorf("HiddenBackupPath(%q) = %q, the rollback target", exe, got)
+	}
+}
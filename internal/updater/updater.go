@@ -2,8 +2,10 @@
 package updater
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime/debug"
@@ -14,9 +16,11 @@ import (
 	"github.com/idelchi/godyl/internal/config/root"
 	"github.com/idelchi/godyl/internal/data"
 	debugi "github.com/idelchi/godyl/internal/debug"
+	"github.com/idelchi/godyl/internal/tools/checksum"
 	"github.com/idelchi/godyl/internal/tools/mode"
 	"github.com/idelchi/godyl/internal/tools/sources"
 	"github.com/idelchi/godyl/internal/tools/sources/github"
+	"github.com/idelchi/godyl/internal/tools/sources/gitlab"
 	"github.com/idelchi/godyl/internal/tools/strategy"
 	"github.com/idelchi/godyl/internal/tools/tags"
 	"github.com/idelchi/godyl/internal/tools/tool"
@@ -32,6 +36,8 @@ import (
 type Godyl struct {
 	Tool    *tool.Tool
 	Version string
+	// PublicKey is the public key to verify the signature of the checksum file with, if set.
+	PublicKey file.File
 }
 
 // NewGodyl creates a new Godyl instance with the provided version and configuration.
@@ -46,21 +52,33 @@ func NewGodyl(v string, cfg *root.Config) Godyl {
 		}
 	}
 
+	path = cmp.Or(cfg.Update.Repo, path)
+
+	source := sources.Source{Type: cmp.Or(cfg.Update.Source, sources.GITHUB)}
+
+	switch source.Type {
+	case sources.GITLAB:
+		source.GitLab = gitlab.GitLab{Server: cfg.Update.Server, Pre: cfg.Update.Pre}
+	case sources.URL:
+		path = "godyl"
+	default:
+		source.GitHub = github.GitHub{Server: cfg.Update.Server, Pre: cfg.Update.Pre}
+	}
+
 	return Godyl{
-		Version: v,
+		Version:   v,
+		PublicKey: cfg.Update.PublicKey,
 		Tool: &tool.Tool{
 			Name: path,
+			URL:  cfg.Update.URL,
 			Version: version.Version{
 				Version:  cfg.Update.Version,
 				Patterns: &version.Patterns{`.*?(\d+\.\d+\.\d+(?:-beta)?).*`},
 			},
-			Mode: mode.Extract,
-			Source: sources.Source{
-				Type: sources.GITHUB,
-				GitHub: github.GitHub{
-					Pre: cfg.Update.Pre,
-				},
-			},
+			Mode:   mode.Extract,
+			Source: source,
+			// The new binary is never installed without verifying it against a checksum
+			Checksum: checksum.Checksum{Type: checksum.File, Value: cfg.Update.Checksum},
 			NoCache:  true,
 			Strategy: cfg.Common.Strategy,
 		},
@@ -77,6 +95,8 @@ type Updater struct {
 	godyl    *Godyl
 	log      *logger.Logger
 	template []byte
+	// target is the path of the binary to update, the running one if empty.
+	target string
 }
 
 // New creates a new Updater instance with the provided configuration.
@@ -97,8 +117,8 @@ func (u *Updater) IsForced() bool {
 // Update performs the self-update process for the godyl tool.
 // Downloads the new version, replaces the current binary, and handles
 // platform-specific cleanup. Returns an error if any step fails.
-func (u *Updater) Update(check bool) error {
-	res := u.godyl.Tool.Resolve(tags.IncludeTags{}, tool.WithUpUntilVersion())
+func (u *Updater) Update(ctx context.Context, check bool) error {
+	res := u.godyl.Tool.Resolve(ctx, tags.IncludeTags{}, tool.WithUpUntilVersion())
 	if res.AsError() != nil {
 		return res.AsError()
 	}
@@ -129,20 +149,29 @@ func (u *Updater) Update(check bool) error {
 		return nil
 	}
 
-	u.log.Infof("Update requested from %q -> %q", u.godyl.Version, u.godyl.Tool.Version.Version)
+	if vversion.LessThan(u.godyl.Tool.Version.Version, u.godyl.Version) {
+		u.log.Infof("Downgrade requested from %q -> %q", u.godyl.Version, u.godyl.Tool.Version.Version)
+	} else {
+		u.log.Infof("Update requested from %q -> %q", u.godyl.Version, u.godyl.Tool.Version.Version)
+	}
 
-	return u.performUpdate(u.godyl.Tool)
+	return u.performUpdate(ctx, u.godyl.Tool)
 }
 
 // PerformUpdate downloads the new version and applies the update.
 // Handles temporary file management and platform-specific cleanup.
-func (u *Updater) performUpdate(tool *tool.Tool) error {
-	if res := tool.Resolve(tags.IncludeTags{}); !res.IsOK() {
+func (u *Updater) performUpdate(ctx context.Context, tool *tool.Tool) error {
+	if res := tool.Resolve(ctx, tags.IncludeTags{}); !res.IsOK() {
 		return res.AsError()
 	}
 
+	// Refuse to replace the binary with one that cannot be verified
+	if err := u.verify(ctx, tool); err != nil {
+		return err
+	}
+
 	// Download the tool to a temporary directory
-	outputDir, err := u.downloadTool(tool)
+	outputDir, err := u.downloadTool(ctx, tool)
 	if err != nil {
 		return err
 	}
@@ -156,11 +185,17 @@ func (u *Updater) performUpdate(tool *tool.Tool) error {
 		}
 	}()
 
-	// Replace the existing binary with the newly downloaded version
+	// Replace the existing binary with the newly downloaded version, keeping the current one
 	newBinaryPath := filepath.Join(outputDir, tool.Exe.Name)
 	debugi.Debug("Replacing binary with: %s", newBinaryPath)
 
-	if err := u.replaceBinary(newBinaryPath); err != nil {
+	binary, err := os.Open(filepath.Clean(newBinaryPath))
+	if err != nil {
+		return fmt.Errorf("opening new binary: %w", err)
+	}
+	defer binary.Close()
+
+	if err := u.replaceBinary(binary); err != nil {
 		return fmt.Errorf("replacing binary: %w", err)
 	}
 
@@ -182,7 +217,7 @@ func (u *Updater) performUpdate(tool *tool.Tool) error {
 
 // DownloadTool retrieves the new version and stores it in a temporary directory.
 // Sets up progress tracking for the download operation.
-func (u *Updater) downloadTool(tool *tool.Tool) (string, error) {
+func (u *Updater) downloadTool(ctx context.Context, tool *tool.Tool) (string, error) {
 	// Create a temporary directory based on the platform
 	dir, err := u.createTempDir()
 	if err != nil {
@@ -196,11 +231,16 @@ func (u *Updater) downloadTool(tool *tool.Tool) (string, error) {
 	// := progress.New()
 	// tracker.Start()
 
-	res := tool.Download(context.TODO(), progress.NewNoop())
+	res := tool.Download(ctx, progress.NewNoop())
 
 	// tracker.Wait()
 
 	if err := res.AsError(); err != nil {
+		// Don't leave a partial download behind
+		if removeErr := folder.New(dir).Remove(); removeErr != nil {
+			u.log.Warnf("Failed to remove temporary folder: %v", removeErr)
+		}
+
 		return "", fmt.Errorf("downloading tool: %w", err)
 	}
 
@@ -234,25 +274,31 @@ func (u *Updater) createTempDir() (string, error) {
 	return dir.Path(), nil
 }
 
-// ReplaceBinary replaces the current executable with the new version.
+// ReplaceBinary replaces the current executable with the new version, keeping the current one as backup.
 // Uses go-update library to handle the replacement process safely.
-func (u *Updater) replaceBinary(newBinaryPath string) error {
-	file, err := os.Open(filepath.Clean(newBinaryPath))
+func (u *Updater) replaceBinary(binary io.Reader) error {
+	exePath, err := u.executable()
 	if err != nil {
-		return fmt.Errorf("opening new binary: %w", err)
+		return err
 	}
-	defer file.Close()
 
-	options := update.Options{}
-	if err := update.Apply(file, options); err != nil {
+	options := update.Options{TargetPath: exePath, OldSavePath: backupPath(exePath).Path()}
+	if err := update.Apply(binary, options); err != nil {
 		return fmt.Errorf("applying update: %w", err)
 	}
 
+	u.log.Debugf("Kept the replaced binary as %q", options.OldSavePath)
+
 	return nil
 }
 
 // CleanupWindows performs Windows-specific post-update cleanup operations.
 // Creates and executes a cleanup script to handle file replacement.
 func (u *Updater) cleanupWindows() error {
-	return createAndRunCleanupScript(u.template, u.log)
+	exePath, err := u.executable()
+	if err != nil {
+		return err
+	}
+
+	return createAndRunCleanupScript(u.template, exePath, u.log)
 }
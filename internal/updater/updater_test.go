@@ -0,0 +1,84 @@
+package updater_test
+
+import (
+	"testing"
+
+	"github.com/idelchi/godyl/internal/config/root"
+	"github.com/idelchi/godyl/internal/config/update"
+	"github.com/idelchi/godyl/internal/tools/checksum"
+	"github.com/idelchi/godyl/internal/tools/sources"
+	"github.com/idelchi/godyl/internal/updater"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+func TestNewGodyl(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		update    update.Update
+		wantType  sources.Type
+		wantName  string
+		wantURL   string
+		wantSrv   string
+		wantCheck string
+	}{
+		{
+			name:     "github by default",
+			update:   update.Update{Repo: "owner/fork", Server: "https://github.example.com"},
+			wantType: sources.GITHUB,
+			wantName: "owner/fork",
+			wantSrv:  "https://github.example.com",
+		},
+		{
+			name:     "gitlab",
+			update:   update.Update{Source: sources.GITLAB, Repo: "group/godyl", Server: "https://gitlab.example.com"},
+			wantType: sources.GITLAB,
+			wantName: "group/godyl",
+			wantSrv:  "https://gitlab.example.com",
+		},
+		{
+			name: "url",
+			update: update.Update{
+				Source:    sources.URL,
+				URL:       "https://example.com/godyl.tar.gz",
+				Version:   "v1.2.3",
+				Checksum:  "https://example.com/checksums.txt",
+				PublicKey: file.New("key.pub"),
+			},
+			wantType:  sources.URL,
+			wantName:  "godyl",
+			wantURL:   "https://example.com/godyl.tar.gz",
+			wantCheck: "https://example.com/checksums.txt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			godyl := updater.NewGodyl("v1.0.0", &root.Config{Update: tt.update})
+
+			got := godyl.Tool
+
+			if got.Source.Type != tt.wantType || got.Name != tt.wantName || got.URL != tt.wantURL {
+				t.Errorf("NewGodyl() source = %q %q %q, want %q %q %q",
+					got.Source.Type, got.Name, got.URL, tt.wantType, tt.wantName, tt.wantURL)
+			}
+
+			if server := got.Source.GitHub.Server + got.Source.GitLab.Server; server != tt.wantSrv {
+				t.Errorf("NewGodyl() server = %q, want %q", server, tt.wantSrv)
+			}
+
+			// The new binary is always verified against a checksum file.
+			want := checksum.Checksum{Type: checksum.File, Value: tt.wantCheck}
+			if got.Checksum != want {
+				t.Errorf("NewGodyl() checksum = %+v, want %+v", got.Checksum, want)
+			}
+
+			if godyl.PublicKey != tt.update.PublicKey {
+				t.Errorf("NewGodyl() public key = %q, want %q", godyl.PublicKey, tt.update.PublicKey)
+			}
+		})
+	}
+}
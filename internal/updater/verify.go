@@ -0,0 +1,85 @@
+package updater
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/idelchi/godyl/internal/tools/checksum"
+	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/signature"
+)
+
+// ErrUnverified is returned when the new binary cannot be verified before replacing the running one.
+var ErrUnverified = errors.New("cannot verify the new binary")
+
+// verify ensures the download of the new binary is verified against a checksum.
+// With a public key, the signature of the checksum file is verified as well,
+// and the download is verified against the checksum listed in the verified file.
+func (u *Updater) verify(ctx context.Context, t *tool.Tool) error {
+	if t.NoVerifyChecksum {
+		u.log.Warn("Checksum verification is disabled, the new binary is not verified")
+
+		return nil
+	}
+
+	if !t.Checksum.IsSet() {
+		return fmt.Errorf("%w: no checksum published for %q", ErrUnverified, t.URL)
+	}
+
+	if u.godyl.PublicKey == "" {
+		return nil
+	}
+
+	return u.verifySignature(ctx, t)
+}
+
+// verifySignature verifies the signature published next to the checksum file as `<checksum file>.sig`
+// against the public key, and pins the checksum of the download to the one listed in the checksum file.
+func (u *Updater) verifySignature(ctx context.Context, t *tool.Tool) error {
+	var checksums string
+
+	if t.Checksum.Type == checksum.File {
+		checksums = t.Checksum.Value
+	}
+
+	if populator := t.GetPopulator(); populator != nil {
+		checksums = cmp.Or(populator.Get("checksum-file"), checksums)
+	}
+
+	if checksums == "" {
+		return fmt.Errorf("%w: no checksum file published for %q to verify the signature of", ErrUnverified, t.URL)
+	}
+
+	key, err := u.godyl.PublicKey.Read()
+	if err != nil {
+		return fmt.Errorf("reading public key: %w", err)
+	}
+
+	content, err := checksum.Fetch(ctx, checksums, t.NoVerifySSL)
+	if err != nil {
+		return fmt.Errorf("%w: fetching checksum file: %w", ErrUnverified, err)
+	}
+
+	sig, err := checksum.Fetch(ctx, checksums+".sig", t.NoVerifySSL)
+	if err != nil {
+		return fmt.Errorf("%w: fetching signature of checksum file: %w", ErrUnverified, err)
+	}
+
+	if err := signature.Verify(key, content, sig); err != nil {
+		return fmt.Errorf("%w: verifying signature of %q: %w", ErrUnverified, checksums, err)
+	}
+
+	typ, value, err := checksum.Lookup(content, file.File(t.URL).Unescape().Base())
+	if err != nil {
+		return fmt.Errorf("%w: %w in checksum file %q", ErrUnverified, err, checksums)
+	}
+
+	t.Checksum = checksum.Checksum{Type: typ, Value: value}
+
+	u.log.Infof("Verified the signature of %q", checksums)
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package updater_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/idelchi/godyl/internal/tools/checksum"
+	"github.com/idelchi/godyl/internal/tools/tool"
+	"github.com/idelchi/godyl/internal/updater"
+	"github.com/idelchi/godyl/pkg/logger"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/signature"
+)
+
+const asset = "godyl_linux_amd64.tar.gz"
+
+// digest is the checksum of the asset listed in the checksum file.
+var digest = strings.Repeat("ab", 32) //nolint:gochecknoglobals	// Shared test fixture.
+
+// newUpdater returns a silent updater verifying signatures with the PEM encoded public key, if any.
+func newUpdater(t *testing.T, publicKey []byte) *updater.Updater {
+	t.Helper()
+
+	log, err := logger.NewCustom(logger.INFO, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	godyl := &updater.Godyl{}
+
+	if publicKey != nil {
+		godyl.PublicKey = file.New(t.TempDir(), "key.pub")
+
+		if err := godyl.PublicKey.Write(publicKey, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return updater.New(godyl, nil, log)
+}
+
+// publicKey returns the PEM encoding of the public key.
+func publicKey(t *testing.T, key any) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// release serves the files of a release, by their name.
+func release(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksums := []byte(digest + "  " + asset + "\n")
+	other := []byte(strings.Repeat("cd", 32) + "  " + asset + "\n")
+
+	tests := []struct {
+		name      string
+		files     map[string][]byte
+		publicKey []byte
+		noVerify  bool
+		noFile    bool
+		want      checksum.Checksum
+		wantErr   error
+	}{
+		{
+			name:     "verification disabled",
+			noVerify: true,
+			noFile:   true,
+		},
+		{
+			name:    "missing checksum asset",
+			noFile:  true,
+			wantErr: updater.ErrUnverified,
+		},
+		{
+			name:  "checksum file without public key",
+			files: map[string][]byte{"checksums.txt": checksums},
+		},
+		{
+			name: "checksum pinned from the signed file",
+			files: map[string][]byte{
+				"checksums.txt":     checksums,
+				"checksums.txt.sig": ed25519.Sign(private, checksums),
+			},
+			publicKey: publicKey(t, public),
+			want:      checksum.Checksum{Type: checksum.SHA256, Value: digest},
+		},
+		{
+			name: "checksum mismatch with the signed file",
+			files: map[string][]byte{
+				"checksums.txt":     other,
+				"checksums.txt.sig": ed25519.Sign(private, checksums),
+			},
+			publicKey: publicKey(t, public),
+			wantErr:   signature.ErrInvalid,
+		},
+		{
+			name: "asset not listed in the signed file",
+			files: map[string][]byte{
+				"checksums.txt":     []byte(digest + "  other.tar.gz\n"),
+				"checksums.txt.sig": ed25519.Sign(private, []byte(digest+"  other.tar.gz\n")),
+			},
+			publicKey: publicKey(t, public),
+			wantErr:   checksum.ErrNoChecksum,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			url := release(t, tt.files)
+
+			tl := &tool.Tool{URL: url + "/" + asset, NoVerifyChecksum: tt.noVerify}
+			if !tt.noFile {
+				tl.Checksum = checksum.Checksum{Type: checksum.File, Value: url + "/checksums.txt"}
+			}
+
+			want := tt.want
+			if want == (checksum.Checksum{}) {
+				want = tl.Checksum
+			}
+
+			err := newUpdater(t, tt.publicKey).Verify(t.Context(), tl)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && tl.Checksum != want {
+				t.Errorf("Verify() checksum = %+v, want %+v", tl.Checksum, want)
+			}
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, forged, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksums := []byte(digest + "  " + asset + "\n")
+
+	tests := []struct {
+		name      string
+		files     map[string][]byte
+		publicKey []byte
+		wantErr   error
+		wantText  string
+	}{
+		{
+			name: "valid signature",
+			files: map[string][]byte{
+				"checksums.txt":     checksums,
+				"checksums.txt.sig": ed25519.Sign(private, checksums),
+			},
+			publicKey: publicKey(t, public),
+		},
+		{
+			name: "bad signature",
+			files: map[string][]byte{
+				"checksums.txt":     checksums,
+				"checksums.txt.sig": ed25519.Sign(forged, checksums),
+			},
+			publicKey: publicKey(t, public),
+			wantErr:   signature.ErrInvalid,
+		},
+		{
+			name: "wrong key type",
+			files: map[string][]byte{
+				"checksums.txt":     checksums,
+				"checksums.txt.sig": ed25519.Sign(private, checksums),
+			},
+			publicKey: publicKey(t, &rsaKey.PublicKey),
+			wantErr:   updater.ErrUnverified,
+			wantText:  "unsupported public key type",
+		},
+		{
+			name:      "missing signature",
+			files:     map[string][]byte{"checksums.txt": checksums},
+			publicKey: publicKey(t, public),
+			wantErr:   updater.ErrUnverified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			url := release(t, tt.files)
+
+			tl := &tool.Tool{
+				URL:      url + "/" + asset,
+				Checksum: checksum.Checksum{Type: checksum.File, Value: url + "/checksums.txt"},
+			}
+
+			err := newUpdater(t, tt.publicKey).VerifySignature(t.Context(), tl)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifySignature() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("VerifySignature() error = %v, want it to contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
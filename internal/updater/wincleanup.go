@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"html/template"
-	"os"
 	"os/exec"
 	"path/filepath"
 
@@ -29,17 +28,18 @@ type cleanupData struct {
 	LogFile string
 }
 
-// CreateAndRunCleanupScript handles Windows-specific cleanup after an update.
+// hiddenBackupPath returns the path of the hidden binary left behind next to the binary at exe
+// by updates that did not keep the replaced binary for a rollback.
+func hiddenBackupPath(exe string) file.File {
+	return file.New(filepath.Dir(exe), "."+filepath.Base(exe)+".old")
+}
+
+// CreateAndRunCleanupScript handles Windows-specific cleanup after an update of the binary at exePath.
 // Creates a batch script from the template, populates it with the necessary paths,
 // and executes it in a minimized window. Returns an error if any step fails.
-func createAndRunCleanupScript(templateContent []byte, log *logger.Logger) error {
-	log.Debug("Issuing a delete command for the old godyl binary")
-
-	// Get the current executable path
-	exePath, err := os.Executable()
-	if err != nil {
-		return fmt.Errorf("getting executable path: %w", err)
-	}
+// The binary kept for a rollback is left in place, only the hidden one left behind by earlier updates is removed.
+func createAndRunCleanupScript(templateContent []byte, exePath string, log *logger.Logger) error {
+	log.Debug("Issuing a delete command for the hidden godyl binary left behind by earlier updates")
 
 	log.Debugf("Executable path: %q", exePath)
 
@@ -50,7 +50,7 @@ func createAndRunCleanupScript(templateContent []byte, log *logger.Logger) error
 	}
 
 	// Prepare file paths
-	oldBinary := file.New(file.New(exePath).Dir(), ".godyl.exe.old")
+	oldBinary := hiddenBackupPath(exePath)
 	batchFile := file.New(folder.Path(), "cleanup.bat")
 	logFile := file.New(folder.Path(), "cleanup_debug.log")
 
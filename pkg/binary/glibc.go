@@ -0,0 +1,51 @@
+package binary
+
+import (
+	"debug/elf"
+	"errors"
+	"strings"
+
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/version"
+)
+
+// RequiredGlibc returns the highest glibc version the symbols imported by the ELF executable are versioned with,
+// such as `2.34`. It returns an empty string for files that are not ELF executables, for statically linked ones
+// and for ones linked against other libraries, such as musl.
+func RequiredGlibc(f file.File) (string, error) {
+	exe, err := elf.Open(f.Path())
+	if err != nil {
+		var format *elf.FormatError
+		if errors.As(err, &format) {
+			return "", nil
+		}
+
+		return "", err //nolint:wrapcheck	// Error does not need additional wrapping.
+	}
+
+	defer exe.Close()
+
+	symbols, err := exe.ImportedSymbols()
+	if err != nil {
+		if errors.Is(err, elf.ErrNoSymbols) {
+			return "", nil
+		}
+
+		return "", err //nolint:wrapcheck	// Error does not need additional wrapping.
+	}
+
+	var required string
+
+	for _, symbol := range symbols {
+		v, ok := strings.CutPrefix(symbol.Version, "GLIBC_")
+		if !ok || version.Parse(v) == nil {
+			continue
+		}
+
+		if required == "" || version.LessThan(required, v) {
+			required = v
+		}
+	}
+
+	return required, nil
+}
@@ -0,0 +1,58 @@
+package binary_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/idelchi/godyl/pkg/binary"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/version"
+)
+
+func TestRequiredGlibc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not an ELF executable requires none", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "script.sh")
+		if err := os.WriteFile(path, []byte("#!/bin/sh\necho hello\n"), 0o600); err != nil {
+			t.Fatalf("writing file: %v", err)
+		}
+
+		got, err := binary.RequiredGlibc(file.New(path))
+		if err != nil {
+			t.Fatalf("RequiredGlibc() unexpected error: %v", err)
+		}
+
+		if got != "" {
+			t.Errorf("RequiredGlibc() = %q, want empty", got)
+		}
+	})
+
+	t.Run("missing file fails", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := binary.RequiredGlibc(file.New(t.TempDir(), "missing")); err == nil {
+			t.Error("RequiredGlibc() = nil, want error")
+		}
+	})
+
+	t.Run("glibc linked executable requires a version", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := binary.RequiredGlibc(file.New("/bin/sh"))
+		if err != nil {
+			t.Skipf("inspecting /bin/sh: %v", err)
+		}
+
+		if got == "" {
+			t.Skip("/bin/sh is not linked against glibc")
+		}
+
+		if version.Parse(got) == nil {
+			t.Errorf("RequiredGlibc() = %q, want a version", got)
+		}
+	})
+}
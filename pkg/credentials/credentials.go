@@ -0,0 +1,138 @@
+// Package credentials maps hosts, optionally followed by a path prefix, to authentication tokens.
+//
+// An entry for `gitlab.example.com/group` applies to all requests to `gitlab.example.com`
+// whose path starts with `/group`. The most specific (longest) matching entry wins.
+package credentials
+
+import (
+	"slices"
+	"strings"
+)
+
+// Token is an authentication token for a host.
+type Token struct {
+	// Host is the host (including the port, if any), optionally followed by a path prefix.
+	Host string `json:"host" mapstructure:"host" yaml:"host"`
+	// Token is the authentication token.
+	Token string `json:"token" mapstructure:"token" mask:"fixed" yaml:"token"`
+}
+
+// Tokens is a collection of tokens keyed by host.
+type Tokens []Token
+
+// Key normalizes a host with an optional path prefix (and optional scheme) to the form `host[/path]`.
+func Key(host string) string {
+	h, p := split(host)
+
+	if p == "" {
+		return h
+	}
+
+	return h + "/" + p
+}
+
+// split returns the lower-cased host and the path prefix without leading and trailing slashes.
+func split(raw string) (host, path string) {
+	if _, rest, ok := strings.Cut(raw, "://"); ok {
+		raw = rest
+	}
+
+	host, path, _ = strings.Cut(raw, "/")
+
+	// Drop any user info, query or fragment
+	if _, h, ok := strings.Cut(host, "@"); ok {
+		host = h
+	}
+
+	path, _, _ = strings.Cut(path, "?")
+	path, _, _ = strings.Cut(path, "#")
+
+	return strings.ToLower(host), strings.Trim(path, "/")
+}
+
+// Lookup returns the token of the most specific entry matching the URL.
+// The URL may also be given as a bare host or `host/path`.
+func (t Tokens) Lookup(rawURL string) (string, bool) {
+	host, path := split(rawURL)
+	if host == "" {
+		return "", false
+	}
+
+	best := -1
+	token := ""
+
+	for _, entry := range t {
+		h, p := split(entry.Host)
+
+		if h != host || !hasPathPrefix(path, p) || entry.Token == "" {
+			continue
+		}
+
+		if len(p) > best {
+			best, token = len(p), entry.Token
+		}
+	}
+
+	return token, best >= 0
+}
+
+// hasPathPrefix reports whether path starts with prefix, on a segment boundary.
+func hasPathPrefix(path, prefix string) bool {
+	if prefix == "" || path == prefix {
+		return true
+	}
+
+	return strings.HasPrefix(path, prefix+"/")
+}
+
+// Get returns the token stored for exactly the given host.
+func (t Tokens) Get(host string) (string, bool) {
+	key := Key(host)
+
+	for _, entry := range t {
+		if Key(entry.Host) == key {
+			return entry.Token, true
+		}
+	}
+
+	return "", false
+}
+
+// With returns a copy of the tokens with the token for the host added or replaced.
+func (t Tokens) With(host, token string) Tokens {
+	tokens := t.Without(host)
+
+	return append(tokens, Token{Host: Key(host), Token: token})
+}
+
+// Without returns a copy of the tokens without the entry for the host.
+func (t Tokens) Without(host string) Tokens {
+	key := Key(host)
+
+	return slices.DeleteFunc(slices.Clone(t), func(entry Token) bool {
+		return Key(entry.Host) == key
+	})
+}
+
+// MergedWith returns a copy of the tokens with the other tokens added,
+// where entries of the other tokens take precedence for the same host.
+func (t Tokens) MergedWith(other Tokens) Tokens {
+	tokens := slices.Clone(t)
+
+	for _, entry := range other {
+		tokens = tokens.With(entry.Host, entry.Token)
+	}
+
+	return tokens
+}
+
+// Hosts returns the normalized hosts of all entries.
+func (t Tokens) Hosts() []string {
+	hosts := make([]string, 0, len(t))
+
+	for _, entry := range t {
+		hosts = append(hosts, Key(entry.Host))
+	}
+
+	return hosts
+}
@@ -0,0 +1,114 @@
+package credentials_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/idelchi/godyl/pkg/credentials"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	tokens := credentials.Tokens{
+		{Host: "github.com", Token: "github"},
+		{Host: "ghe.example.com", Token: "ghe"},
+		{Host: "gitlab.example.com", Token: "gitlab"},
+		{Host: "https://gitlab.example.com/group/", Token: "group"},
+		{Host: "gitlab.example.com/group/sub", Token: "sub"},
+		{Host: "localhost:8080", Token: "local"},
+	}
+
+	tests := []struct {
+		name   string
+		url    string
+		want   string
+		wantOK bool
+	}{
+		{name: "bare host", url: "github.com", want: "github", wantOK: true},
+		{name: "full url", url: "https://github.com/idelchi/godyl/releases", want: "github", wantOK: true},
+		{name: "host is case insensitive", url: "https://GHE.example.com/api/v3", want: "ghe", wantOK: true},
+		{name: "host without path prefix", url: "https://gitlab.example.com/other/project", want: "gitlab", wantOK: true},
+		{name: "path prefix", url: "https://gitlab.example.com/group/project", want: "group", wantOK: true},
+		{name: "longest path prefix", url: "https://gitlab.example.com/group/sub/project", want: "sub", wantOK: true},
+		{name: "prefix on segment boundary", url: "https://gitlab.example.com/groups/project", want: "gitlab", wantOK: true},
+		{name: "port is part of the host", url: "http://localhost:8080/file", want: "local", wantOK: true},
+		{name: "other port", url: "http://localhost:9090/file", wantOK: false},
+		{name: "subdomain does not match", url: "https://api.github.com/repos", wantOK: false},
+		{name: "empty", url: "", wantOK: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tokens.Lookup(tc.url)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("Lookup(%q) = (%q, %v), want (%q, %v)", tc.url, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestWithAndWithout(t *testing.T) {
+	t.Parallel()
+
+	tokens := credentials.Tokens{{Host: "github.com", Token: "old"}}
+
+	tokens = tokens.With("https://GitHub.com/", "new").With("gitlab.com/group", "gitlab")
+
+	if got, _ := tokens.Get("github.com"); got != "new" {
+		t.Errorf("Get(github.com) = %q, want %q", got, "new")
+	}
+
+	if want := []string{"github.com", "gitlab.com/group"}; !slices.Equal(tokens.Hosts(), want) {
+		t.Errorf("Hosts() = %v, want %v", tokens.Hosts(), want)
+	}
+
+	tokens = tokens.Without("github.com")
+
+	if _, ok := tokens.Get("github.com"); ok {
+		t.Error("Get(github.com) found a token after Without")
+	}
+
+	merged := credentials.Tokens{{Host: "a.com", Token: "a"}, {Host: "b.com", Token: "b"}}.
+		MergedWith(credentials.Tokens{{Host: "b.com", Token: "override"}})
+
+	if got, _ := merged.Get("b.com"); got != "override" {
+		t.Errorf("MergedWith: Get(b.com) = %q, want %q", got, "override")
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	t.Parallel()
+
+	netrc := []byte(`# Comment
+machine github.com login user password github-secret
+macdef init
+machine gitlab.com password macro-secret
+
+machine GitLab.com
+  login user
+  password gitlab-secret
+default login anonymous password default-secret
+`)
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "github.com", want: "github-secret"},
+		{host: "gitlab.com", want: "gitlab-secret"},
+		{host: "example.com", want: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			t.Parallel()
+
+			if got := credentials.ParseNetrc(netrc, tc.host); got != tc.want {
+				t.Errorf("ParseNetrc(%q) = %q, want %q", tc.host, got, tc.want)
+			}
+		})
+	}
+}
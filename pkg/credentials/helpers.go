@@ -0,0 +1,192 @@
+package credentials
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Helpers providing tokens from credentials configured outside of godyl.
+const (
+	// Git queries `git credential fill`, covering configured credential helpers (including `gh auth`).
+	Git = "git"
+	// Netrc reads the `.netrc` file (or the file set in `NETRC`).
+	Netrc = "netrc"
+)
+
+// gitTimeout limits the time a credential helper may take.
+const gitTimeout = 10 * time.Second
+
+// cache holds the results of the helpers, keyed by helper and host.
+var cache sync.Map
+
+// result is a cached helper result.
+type result struct {
+	token string
+	ok    bool
+}
+
+// Fill queries the helpers in order for a token for the host,
+// returning the token and the helper that provided it.
+// Failing helpers are treated as not having a token.
+func Fill(helpers []string, host string) (token, origin string, ok bool) {
+	host, _ = split(host)
+	if host == "" {
+		return "", "", false
+	}
+
+	for _, helper := range helpers {
+		key := helper + "\x00" + host
+
+		cached, found := cache.Load(key)
+		if !found {
+			token, ok := query(helper, host)
+			cached, _ = cache.LoadOrStore(key, result{token: token, ok: ok})
+		}
+
+		if res, _ := cached.(result); res.ok {
+			return res.token, helper, true
+		}
+	}
+
+	return "", "", false
+}
+
+// query runs a single helper.
+func query(helper, host string) (string, bool) {
+	var (
+		token string
+		err   error
+	)
+
+	switch helper {
+	case Git:
+		token, err = gitCredential(host)
+	case Netrc:
+		token, err = netrc(host)
+	default:
+		return "", false
+	}
+
+	return token, err == nil && token != ""
+}
+
+// gitCredential returns the password `git credential fill` provides for the host.
+// Prompting is disabled, such that only stored credentials are returned.
+func gitCredential(host string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=https\nhost=%s\n\n", host))
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "GCM_INTERACTIVE=never")
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running git credential fill: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+
+	for scanner.Scan() {
+		if password, ok := strings.CutPrefix(scanner.Text(), "password="); ok {
+			return password, nil
+		}
+	}
+
+	return "", nil
+}
+
+// netrc returns the password of the machine entry for the host in the netrc file.
+// The `default` entry is not used, to avoid sending credentials to unrelated hosts.
+func netrc(host string) (string, error) {
+	path := os.Getenv("NETRC")
+
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+
+		name := ".netrc"
+		if runtime.GOOS == "windows" {
+			name = "_netrc"
+		}
+
+		path = filepath.Join(home, name)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return ParseNetrc(data, host), nil
+}
+
+// ParseNetrc returns the password of the machine entry for the host in the netrc contents.
+func ParseNetrc(data []byte, host string) string {
+	var (
+		machine string
+		// expect is the keyword whose value is expected next
+		expect string
+		// macro is set while skipping the body of a macro definition, which ends with an empty line
+		macro bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if macro {
+			macro = line != ""
+
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		for _, field := range strings.Fields(line) {
+			switch expect {
+			case "machine":
+				machine, expect = strings.ToLower(field), ""
+
+				continue
+			case "password":
+				if machine != "" && machine == host {
+					return field
+				}
+
+				expect = ""
+
+				continue
+			case "login", "account", "macdef":
+				expect = ""
+
+				continue
+			}
+
+			switch field {
+			case "default":
+				machine = ""
+			case "macdef":
+				expect, macro = field, true
+			case "machine", "password", "login", "account":
+				expect = field
+			}
+		}
+	}
+
+	return ""
+}
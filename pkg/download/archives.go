@@ -0,0 +1,245 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	neturl "net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-getter/v2"
+
+	"github.com/idelchi/godyl/internal/debug"
+	"github.com/idelchi/godyl/pkg/flock"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+// tmpPrefix prefixes the folders of downloads in progress within the archive cache.
+const tmpPrefix = ".tmp-"
+
+// lockSuffix suffixes the lock files of the entries within the archive cache.
+const lockSuffix = ".lock"
+
+// ArchiveCache stores downloaded files keyed by their URL and checksum, such that repeated downloads
+// are served locally. Only downloads verified against a checksum are stored, and stored files are verified
+// against it again on reuse. The least recently used entries are evicted beyond the maximum size.
+type ArchiveCache struct {
+	dir     folder.Folder
+	maxSize int64
+	mu      sync.Mutex
+}
+
+// NewArchiveCache returns an ArchiveCache storing the files in dir, holding at most maxSize bytes.
+func NewArchiveCache(dir folder.Folder, maxSize int64) *ArchiveCache {
+	return &ArchiveCache{dir: dir, maxSize: maxSize}
+}
+
+// archiveKey returns the key for the file downloaded from url and verified with the checksum query.
+func archiveKey(url, checksum string) string {
+	sum := sha256.Sum256([]byte(url + "\n" + checksum))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheable reports whether the download can be stored, which requires a checksum tying the content to the URL.
+// Checksum files are excluded, as their contents may change.
+func cacheable(checksum string) bool {
+	return checksum != "" && !strings.HasPrefix(checksum, "checksum=file:")
+}
+
+// archiveName returns the name of the file to store the download from url as,
+// or an empty string if the URL does not name a file.
+func archiveName(url string) string {
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return ""
+	}
+
+	name := path.Base(u.Path)
+	if name == "." || name == "/" {
+		return ""
+	}
+
+	return name
+}
+
+// lock locks the entry under the key, within and across processes, such that it is neither
+// downloaded twice nor removed or replaced while in use.
+func (a *ArchiveCache) lock(ctx context.Context, key string) (*flock.Lock, error) {
+	// Downloads may hold private data, so keep them to the user.
+	if err := a.dir.Create(0o700); err != nil {
+		return nil, err
+	}
+
+	entry := flock.New(a.dir.WithFile(key + lockSuffix))
+	if err := entry.Lock(ctx); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// lookup returns the file stored under the key, marking it as recently used.
+// Callers extracting or replacing the entry must hold its lock.
+func (a *ArchiveCache) lookup(key string) (file.File, bool) {
+	entry := a.dir.Join(key)
+
+	files, err := entry.ListFiles()
+	if err != nil || len(files) != 1 {
+		return file.New(), false
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(entry.Path(), now, now); err != nil {
+		debug.Debug("marking %q as used: %v", entry, err)
+	}
+
+	return files[0], true
+}
+
+// Lookup returns the file stored for the download from url verified with the checksum query, if any.
+func (a *ArchiveCache) Lookup(url, checksum string) (file.File, bool) {
+	if !cacheable(checksum) {
+		return file.New(), false
+	}
+
+	return a.lookup(archiveKey(url, checksum))
+}
+
+// IsArchive reports whether the file with the name is extracted when downloaded, judged by its extension.
+func IsArchive(name string) bool {
+	name = strings.ToLower(name)
+
+	for extension := range getter.Decompressors {
+		if strings.HasSuffix(name, "."+extension) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remove deletes the file stored under the key.
+func (a *ArchiveCache) remove(key string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.dir.Join(key).Remove()
+}
+
+// prepare creates a temporary folder within the cache to download a file into.
+func (a *ArchiveCache) prepare() (folder.Folder, error) {
+	// Downloads may hold private data, so keep them to the user.
+	if err := a.dir.Create(0o700); err != nil {
+		return folder.New(), err
+	}
+
+	return folder.CreateRandomInDir(a.dir.Path(), tmpPrefix+"*")
+}
+
+// store moves the temporary folder holding a downloaded file into the cache under the key,
+// evicting the least recently used entries beyond the maximum size.
+func (a *ArchiveCache) store(key string, tmp folder.Folder) (file.File, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := os.Rename(tmp.Path(), a.dir.Join(key).Path()); err != nil {
+		// The same file may have been stored by a concurrent download in the meantime.
+		if stored, ok := a.lookup(key); ok {
+			return stored, tmp.Remove()
+		}
+
+		return file.New(), fmt.Errorf("storing download in archive cache: %w", err)
+	}
+
+	if err := a.evict(key); err != nil {
+		debug.Debug("evicting from archive cache: %v", err)
+	}
+
+	stored, ok := a.lookup(key)
+	if !ok {
+		return file.New(), errors.New("storing download in archive cache: stored file not found")
+	}
+
+	return stored, nil
+}
+
+// evict removes the least recently used entries, except for the one to keep, until the cache fits its maximum size.
+// Entries locked elsewhere are in use and kept as well.
+func (a *ArchiveCache) evict(keep string) error {
+	folders, err := a.dir.ListFolders()
+	if err != nil {
+		return err
+	}
+
+	type entry struct {
+		used   time.Time
+		folder folder.Folder
+		size   int64
+	}
+
+	var (
+		entries []entry
+		total   int64
+	)
+
+	for _, f := range folders {
+		if strings.HasPrefix(f.Base(), tmpPrefix) {
+			continue
+		}
+
+		info, err := f.Info()
+		if err != nil {
+			return err
+		}
+
+		size, err := f.Size()
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, entry{used: info.ModTime(), folder: f, size: size})
+		total += size
+	}
+
+	slices.SortFunc(entries, func(x, y entry) int {
+		return x.used.Compare(y.used)
+	})
+
+	for _, e := range entries {
+		if total <= a.maxSize {
+			break
+		}
+
+		if e.folder.Base() == keep {
+			continue
+		}
+
+		entry := flock.New(a.dir.WithFile(e.folder.Base() + lockSuffix))
+		if err := entry.TryLock(); err != nil {
+			debug.Debug("keeping %q in archive cache: %v", e.folder, err)
+
+			continue
+		}
+
+		debug.Debug("evicting %q from archive cache", e.folder)
+
+		err := e.folder.Remove()
+
+		if err := errors.Join(err, entry.Unlock()); err != nil {
+			return err
+		}
+
+		total -= e.size
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
@@ -16,8 +17,12 @@ import (
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
 
 	"github.com/idelchi/godyl/internal/debug"
+	"github.com/idelchi/godyl/pkg/credentials"
+	"github.com/idelchi/godyl/pkg/download/progress"
 	"github.com/idelchi/godyl/pkg/generic"
+	"github.com/idelchi/godyl/pkg/mirror"
 	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
 )
 
 // Downloader manages download settings.
@@ -28,6 +33,11 @@ type Downloader struct {
 	headTimeout        time.Duration
 	insecureSkipVerify bool
 	checksum           string
+	tokens             credentials.Tokens
+	archives           *ArchiveCache
+	partials           folder.Folder
+	mirrors            mirror.Rules
+	transport          *http.Transport
 
 	// retry settings
 	maxRetries   int
@@ -82,27 +92,129 @@ func URLWithChecksum(url, query string) string {
 }
 
 // Download fetches url to output (archives auto‑extracted).
-func (d Downloader) Download(url, output string, header ...http.Header) (file.File, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), d.contextTimeout)
+// The download is aborted when ctx is cancelled or the context timeout expires.
+// A failed download removes output, unless it existed beforehand.
+// With an archive cache, downloads verified against a checksum are served from and stored in the cache.
+// With a folder for partial downloads, interrupted downloads are resumed from where they stopped.
+// With mirrors, the URL and the URL of a checksum file are rewritten to the first matching mirror.
+func (d Downloader) Download(ctx context.Context, url, output string, header ...http.Header) (file.File, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.contextTimeout)
 	defer cancel()
 
+	if !generic.IsURL(url) {
+		return file.New(), fmt.Errorf("%w: invalid URL: %q", ErrDownload, url)
+	}
+
+	mirrored, rule, ok := d.mirrors.Rewrite(url)
+	if !ok {
+		return d.fetch(ctx, url, output, header...)
+	}
+
+	debug.Debug("downloading %q from mirror %q", url, mirrored)
+
+	m := d
+	m.checksum = d.mirroredChecksum()
+
+	// The credentials for the original host are not meant for the mirror.
+	headers := make([]http.Header, 0, len(header)+1)
+
+	for _, h := range header {
+		h = h.Clone()
+
+		for _, key := range []string{"Authorization", "Private-Token", "Job-Token"} {
+			h.Del(key)
+		}
+
+		headers = append(headers, h)
+	}
+
+	headers = append(headers, rule.Header())
+
+	downloaded, err := m.fetch(ctx, mirrored, output, headers...)
+	if err == nil || !rule.Fallback || !m.notFound(ctx, mirrored, headers...) {
+		return downloaded, err
+	}
+
+	debug.Debug("%q not found on mirror, falling back to %q", mirrored, url)
+
+	return d.fetch(ctx, url, output, header...)
+}
+
+// mirroredChecksum returns the checksum query with the URL of a checksum file rewritten to its mirror.
+func (d Downloader) mirroredChecksum() string {
+	if url, ok := strings.CutPrefix(d.checksum, "checksum=file:"); ok {
+		if mirrored, _, ok := d.mirrors.Rewrite(url); ok {
+			return "checksum=file:" + mirrored
+		}
+	}
+
+	return d.checksum
+}
+
+// notFound reports whether the server does not have the file at url, as reported for a HEAD request.
+// The go-getter only reports the status code of a failed download in the message of its error.
+func (d Downloader) notFound(ctx context.Context, url string, header ...http.Header) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false
+	}
+
+	for _, h := range header {
+		for k, vv := range h {
+			for _, v := range vv {
+				req.Header.Add(k, v)
+			}
+		}
+	}
+
+	resp, err := (&http.Client{Transport: d.httpTransport()}).Do(req)
+	if err != nil {
+		debug.Debug("checking %q: %v", url, err)
+
+		return false
+	}
+
+	resp.Body.Close()
+
+	return resp.StatusCode == http.StatusNotFound
+}
+
+// fetch downloads url to output.
+func (d Downloader) fetch(ctx context.Context, url, output string, header ...http.Header) (file.File, error) {
+	httpGetter := d.httpGetter(url, header...)
+
+	if name := archiveName(url); d.archives != nil && cacheable(d.checksum) && name != "" {
+		return d.downloadCached(ctx, httpGetter, url, name, output)
+	}
+
+	progress.Enter(ctx, progress.Downloading)
+
+	return d.get(ctx, httpGetter, &getter.Request{
+		Src:              URLWithChecksum(url, d.checksum),
+		Dst:              output,
+		GetMode:          getter.ModeAny,
+		ProgressListener: d.progressListener,
+	})
+}
+
+// httpGetter returns the getter to fetch url with.
+func (d Downloader) httpGetter(url string, header ...http.Header) getter.Getter {
 	// retryable HTTP client
 	client := retryablehttp.NewClient()
 
 	client.Logger = nil // silence default logging
 	client.RetryMax = d.maxRetries
+	// Resumable downloads are retried by resuming them instead, such that retries don't multiply
+	if d.partials.IsSet() {
+		client.RetryMax = 0
+	}
+
 	client.RetryWaitMin = d.retryWaitMin
 	client.RetryWaitMax = d.retryWaitMax
-	client.HTTPClient = cleanhttp.DefaultClient()
+	client.HTTPClient = &http.Client{Transport: d.httpTransport()}
 
 	httpClient := client.StandardClient()
 
-	if d.insecureSkipVerify {
-		httpClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec   // Only set if explicitly requested
-		}
-	}
-
 	// merge headers
 	headers := make(http.Header)
 
@@ -114,7 +226,12 @@ func (d Downloader) Download(url, output string, header ...http.Header) (file.Fi
 		}
 	}
 
-	httpGetter := &getter.HttpGetter{
+	// Authenticate with the token for the host, unless the caller already provided credentials
+	if token, ok := d.tokens.Lookup(url); ok && headers.Get("Authorization") == "" && headers.Get("PRIVATE-TOKEN") == "" {
+		headers.Set("Authorization", "Bearer "+token)
+	}
+
+	g := &getter.HttpGetter{
 		Netrc:                 true,
 		XTerraformGetDisabled: true,
 		HeadFirstTimeout:      d.headTimeout,
@@ -123,27 +240,141 @@ func (d Downloader) Download(url, output string, header ...http.Header) (file.Fi
 		Header:                headers,
 	}
 
-	if !generic.IsURL(url) {
-		return file.New(), fmt.Errorf("%w: invalid URL: %q", ErrDownload, url)
+	if d.partials.IsSet() {
+		return d.resumable(g)
 	}
 
-	req := &getter.Request{
-		Src:              URLWithChecksum(url, d.checksum),
-		Dst:              output,
-		GetMode:          getter.ModeAny,
+	return g
+}
+
+// httpTransport returns the transport to send the requests with.
+func (d Downloader) httpTransport() *http.Transport {
+	transport := d.transport
+	if transport == nil {
+		transport = cleanhttp.DefaultPooledTransport()
+	}
+
+	if d.insecureSkipVerify {
+		transport = transport.Clone()
+
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+
+		transport.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec   // Only set if explicitly requested
+	}
+
+	return transport
+}
+
+// downloadCached fetches url into the archive cache unless already stored, and extracts it from there to output.
+func (d Downloader) downloadCached(ctx context.Context, g getter.Getter, url, name, output string) (file.File, error) {
+	key := archiveKey(url, d.checksum)
+
+	// Hold the entry throughout, such that it is not removed while extracted or downloaded by others meanwhile.
+	entry, err := d.archives.lock(ctx, key)
+	if err != nil {
+		return file.New(), fmt.Errorf("%w: locking archive cache: %w", ErrDownload, err)
+	}
+
+	defer func() {
+		if err := entry.Unlock(); err != nil {
+			debug.Debug("unlocking %q: %v", entry.Path(), err)
+		}
+	}()
+
+	if archive, ok := d.archives.lookup(key); ok {
+		debug.Debug("using cached archive %q for %q", archive, url)
+
+		extracted, err := d.extract(ctx, archive, output)
+		if err == nil {
+			return extracted, nil
+		}
+
+		// A corrupted or tampered file fails verification, so discard it and download it again.
+		debug.Debug("discarding cached archive %q: %v", archive, err)
+
+		if err := d.archives.remove(key); err != nil {
+			return file.New(), fmt.Errorf("%w: removing cached archive: %w", ErrDownload, err)
+		}
+	}
+
+	tmp, err := d.archives.prepare()
+	if err != nil {
+		return file.New(), fmt.Errorf("%w: preparing archive cache: %w", ErrDownload, err)
+	}
+
+	defer func() {
+		if err := tmp.Remove(); err != nil {
+			debug.Debug("removing %q: %v", tmp, err)
+		}
+	}()
+
+	progress.Enter(ctx, progress.Downloading)
+
+	// Download the file as-is, such that it's stored before being extracted.
+	if _, err := d.get(ctx, g, &getter.Request{
+		Src:              URLWithChecksum(URLWithChecksum(url, "archive=false"), d.checksum),
+		Dst:              tmp.WithFile(name).Path(),
+		GetMode:          getter.ModeFile,
 		ProgressListener: d.progressListener,
+	}); err != nil {
+		return file.New(), err
+	}
+
+	archive, err := d.archives.store(key, tmp)
+	if err != nil {
+		return file.New(), fmt.Errorf("%w: %w", ErrDownload, err)
+	}
+
+	return d.extract(ctx, archive, output)
+}
+
+// extract verifies the stored archive against the checksum and extracts it to output.
+func (d Downloader) extract(ctx context.Context, archive file.File, output string) (file.File, error) {
+	if d.checksum != "" {
+		progress.Enter(ctx, progress.Verifying)
+	}
+
+	return d.get(ctx, &getter.FileGetter{}, &getter.Request{
+		Src:     URLWithChecksum(archive.Path(), d.checksum),
+		Dst:     output,
+		GetMode: getter.ModeAny,
+		Copy:    true,
+	})
+}
+
+// get fetches the request with the getter.
+// On failure, the destination is removed unless it existed beforehand.
+func (d Downloader) get(ctx context.Context, g getter.Getter, req *getter.Request) (file.File, error) {
+	debug.Debug("downloading %q to %q", req.Src, req.Dst)
+
+	_, statErr := os.Lstat(req.Dst)
+	existed := statErr == nil
+
+	// Report the phases of verifying and extracting the download, as the go-getter performs them internally.
+	if req.ProgressListener != nil && d.checksum != "" {
+		req.ProgressListener = &verifying{ProgressTracker: req.ProgressListener, ctx: ctx}
 	}
 
-	debug.Debug("downloading %q to %q", URLWithChecksum(url, d.checksum), output)
+	client := &getter.Client{
+		Getters:       []getter.Getter{g},
+		Decompressors: decompressors(ctx),
+	}
 
-	res, err := (&getter.Client{Getters: []getter.Getter{httpGetter}}).Get(ctx, req)
+	res, err := client.Get(ctx, req)
 	if err != nil {
 		debug.Debug("error: %v", err)
 
+		// Don't leave a partial download behind
+		if !existed {
+			err = errors.Join(err, os.RemoveAll(req.Dst))
+		}
+
 		return file.New(), fmt.Errorf("%w: getting file: %w", ErrDownload, err)
 	}
 
-	debug.Debug("downloaded %q to %q", url, res.Dst)
+	debug.Debug("downloaded %q to %q", req.Src, res.Dst)
 
 	return file.New(res.Dst), nil
 }
@@ -1,15 +1,31 @@
 package download_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/idelchi/godyl/pkg/credentials"
 	"github.com/idelchi/godyl/pkg/download"
+	"github.com/idelchi/godyl/pkg/download/progress"
+	"github.com/idelchi/godyl/pkg/mirror"
+	"github.com/idelchi/godyl/pkg/path/folder"
 )
 
 func TestURLWithChecksum(t *testing.T) {
@@ -112,7 +128,7 @@ func TestDownload(t *testing.T) {
 
 		d := download.New(download.WithContextTimeout(10 * time.Second))
 
-		f, err := d.Download(srv.URL, dst)
+		f, err := d.Download(t.Context(), srv.URL, dst)
 		if err != nil {
 			t.Fatalf("Download(%q): unexpected error: %v", srv.URL, err)
 		}
@@ -138,7 +154,7 @@ func TestDownload(t *testing.T) {
 
 		d := download.New(download.WithContextTimeout(10*time.Second), download.WithMaxRetries(0))
 
-		_, err := d.Download(notFoundSrv.URL, dst)
+		_, err := d.Download(t.Context(), notFoundSrv.URL, dst)
 		if err == nil {
 			t.Fatal("Download(404): expected error, got nil")
 		}
@@ -149,7 +165,7 @@ func TestDownload(t *testing.T) {
 
 		d := download.New(download.WithContextTimeout(5 * time.Second))
 
-		_, err := d.Download("not-a-url", t.TempDir())
+		_, err := d.Download(t.Context(), "not-a-url", t.TempDir())
 		if err == nil {
 			t.Fatal("Download(invalid URL): expected error, got nil")
 		}
@@ -172,12 +188,37 @@ func TestDownload500(t *testing.T) {
 		download.WithMaxRetries(0),
 	)
 
-	_, err := d.Download(srv.URL, dst)
+	_, err := d.Download(t.Context(), srv.URL, dst)
 	if err == nil {
 		t.Fatal("Download(500): expected error, got nil")
 	}
 }
 
+func TestDownloadCancelled(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "hello")
+	}))
+	t.Cleanup(srv.Close)
+
+	dst := filepath.Join(t.TempDir(), "out.txt")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	d := download.New(download.WithContextTimeout(10 * time.Second))
+
+	_, err := d.Download(ctx, srv.URL, dst)
+	if err == nil {
+		t.Fatal("Download(cancelled): expected error, got nil")
+	}
+
+	if _, statErr := os.Stat(dst); statErr == nil {
+		t.Errorf("Download(cancelled): expected no file at %q", dst)
+	}
+}
+
 func TestDownloadToSubdir(t *testing.T) {
 	t.Parallel()
 
@@ -193,7 +234,7 @@ func TestDownloadToSubdir(t *testing.T) {
 
 	d := download.New(download.WithContextTimeout(10 * time.Second))
 
-	f, err := d.Download(srv.URL, dst)
+	f, err := d.Download(t.Context(), srv.URL, dst)
 	if err != nil {
 		t.Fatalf("Download(subdir): unexpected error: %v", err)
 	}
@@ -207,3 +248,580 @@ func TestDownloadToSubdir(t *testing.T) {
 		t.Errorf("Download(subdir): content = %q, want %q", string(got), body)
 	}
 }
+
+func TestDownloadWithTokens(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+	}))
+	t.Cleanup(srv.Close)
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	tests := []struct {
+		name   string
+		tokens credentials.Tokens
+		header http.Header
+		want   string
+	}{
+		{
+			name:   "token for host is sent",
+			tokens: credentials.Tokens{{Host: host, Token: "secret"}},
+			want:   "Bearer secret",
+		},
+		{
+			name:   "token for other host is not sent",
+			tokens: credentials.Tokens{{Host: "example.com", Token: "secret"}},
+			want:   "",
+		},
+		{
+			name:   "explicit header takes precedence",
+			tokens: credentials.Tokens{{Host: host, Token: "secret"}},
+			header: http.Header{"Authorization": []string{"token explicit"}},
+			want:   "token explicit",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dst := filepath.Join(t.TempDir(), "out.txt")
+
+			d := download.New(download.WithContextTimeout(10*time.Second), download.WithTokens(tc.tokens))
+
+			f, err := d.Download(t.Context(), srv.URL, dst, tc.header)
+			if err != nil {
+				t.Fatalf("Download(%q): unexpected error: %v", srv.URL, err)
+			}
+
+			got, err := os.ReadFile(string(f))
+			if err != nil {
+				t.Fatalf("ReadFile(%q): %v", string(f), err)
+			}
+
+			if string(got) != tc.want {
+				t.Errorf("Authorization header = %q, want %q", string(got), tc.want)
+			}
+		})
+	}
+}
+
+// tarball returns a gzipped tarball holding a single executable.
+func tarball(t *testing.T, name, content string) *bytes.Buffer {
+	t.Helper()
+
+	var archive bytes.Buffer
+
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := io.WriteString(tw, content); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := errors.Join(tw.Close(), gz.Close()); err != nil {
+		t.Fatal(err)
+	}
+
+	return &archive
+}
+
+func TestDownloadWithArchiveCache(t *testing.T) {
+	t.Parallel()
+
+	const content = "#!/bin/sh\necho tool\n"
+
+	archive := tarball(t, "tool", content)
+
+	sum := sha256.Sum256(archive.Bytes())
+	checksum := "checksum=sha256:" + hex.EncodeToString(sum[:])
+
+	var gets atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+
+		_, _ = w.Write(archive.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+
+	url := srv.URL + "/tool.tar.gz"
+	dir := t.TempDir()
+	cache := download.NewArchiveCache(folder.New(dir), 1<<20)
+
+	d := download.New(
+		download.WithContextTimeout(10*time.Second),
+		download.WithChecksum(checksum),
+		download.WithArchiveCache(cache),
+	)
+
+	install := func() {
+		t.Helper()
+
+		dst := t.TempDir()
+
+		if _, err := d.Download(t.Context(), url, dst); err != nil {
+			t.Fatalf("Download(%q): unexpected error: %v", url, err)
+		}
+
+		got, err := os.ReadFile(filepath.Join(dst, "tool"))
+		if err != nil {
+			t.Fatalf("reading extracted file: %v", err)
+		}
+
+		if string(got) != content {
+			t.Errorf("extracted content = %q, want %q", got, content)
+		}
+	}
+
+	// Repeated downloads are served from the cache.
+	install()
+	install()
+
+	if got := gets.Load(); got != 1 {
+		t.Errorf("downloads after reuse = %d, want 1", got)
+	}
+
+	// A corrupted archive fails verification and is downloaded again.
+	stored, err := filepath.Glob(filepath.Join(dir, "*", "tool.tar.gz"))
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("finding stored archive: %v (%v)", stored, err)
+	}
+
+	if err := os.WriteFile(stored[0], []byte("corrupted"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	install()
+
+	if got := gets.Load(); got != 2 {
+		t.Errorf("downloads after corruption = %d, want 2", got)
+	}
+}
+
+func TestDownloadWithArchiveCacheConcurrent(t *testing.T) {
+	t.Parallel()
+
+	archive := tarball(t, "tool", "#!/bin/sh\necho tool\n")
+
+	sum := sha256.Sum256(archive.Bytes())
+	checksum := "checksum=sha256:" + hex.EncodeToString(sum[:])
+
+	var gets atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+
+		// Keep the download in progress long enough for the others to wait on it.
+		time.Sleep(100 * time.Millisecond)
+
+		_, _ = w.Write(archive.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+
+	url := srv.URL + "/tool.tar.gz"
+	dir := folder.New(t.TempDir())
+
+	var wg sync.WaitGroup
+
+	// Separate caches on the same folder stand in for separate processes.
+	for range 4 {
+		wg.Go(func() {
+			d := download.New(
+				download.WithContextTimeout(10*time.Second),
+				download.WithChecksum(checksum),
+				download.WithArchiveCache(download.NewArchiveCache(dir, 1<<20)),
+			)
+
+			if _, err := d.Download(t.Context(), url, t.TempDir()); err != nil {
+				t.Errorf("Download(%q): unexpected error: %v", url, err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if got := gets.Load(); got != 1 {
+		t.Errorf("concurrent downloads = %d, want 1", got)
+	}
+}
+
+func TestDownloadResume(t *testing.T) {
+	t.Parallel()
+
+	const size = 10000
+
+	// server serves the content, dropping the connection halfway through the first drops responses.
+	type server struct {
+		content string
+		etag    string
+		drops   atomic.Int32
+		ranges  chan string
+	}
+
+	newServer := func(t *testing.T, s *server) string {
+		t.Helper()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", s.etag)
+
+			if r.Method != http.MethodGet {
+				http.ServeContent(w, r, "tool", time.Time{}, strings.NewReader(s.content))
+
+				return
+			}
+
+			s.ranges <- r.Header.Get("Range")
+
+			if s.drops.Add(-1) >= 0 {
+				w.Header().Set("Content-Length", strconv.Itoa(len(s.content)))
+				_, _ = io.WriteString(w, s.content[:len(s.content)/2])
+
+				w.(http.Flusher).Flush()
+
+				panic(http.ErrAbortHandler)
+			}
+
+			http.ServeContent(w, r, "tool", time.Time{}, strings.NewReader(s.content))
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv.URL + "/tool"
+	}
+
+	download := func(t *testing.T, url, partials string, retries int) (string, error) {
+		t.Helper()
+
+		d := download.New(
+			download.WithContextTimeout(10*time.Second),
+			download.WithMaxRetries(retries),
+			download.WithRetryWaits(time.Millisecond, time.Millisecond),
+			download.WithResume(folder.New(partials)),
+		)
+
+		f, err := d.Download(t.Context(), url, t.TempDir())
+		if err != nil {
+			return "", err
+		}
+
+		got, err := os.ReadFile(f.Path())
+
+		return string(got), err
+	}
+
+	v1 := strings.Repeat("a", size)
+	v2 := strings.Repeat("b", size)
+
+	tests := []struct {
+		name string
+		// changed is the content served after the first download failed, if any
+		changed string
+		retries int
+		// ranges are the range requests expected of the successful download
+		ranges []string
+	}{
+		{
+			name:    "resumes within retries",
+			retries: 1,
+			ranges:  []string{"", "bytes=5000-"},
+		},
+		{
+			name:   "resumes in a later download",
+			ranges: []string{"bytes=5000-"},
+		},
+		{
+			name:    "starts over when the file changed",
+			changed: v2,
+			ranges:  []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &server{content: v1, etag: `"v1"`, ranges: make(chan string, 10)}
+			s.drops.Store(1)
+
+			url := newServer(t, s)
+			partials := t.TempDir()
+
+			want := v1
+
+			if tt.retries == 0 {
+				if _, err := download(t, url, partials, 0); err == nil {
+					t.Fatalf("Download(%q): expected the dropped connection to fail", url)
+				}
+
+				if got := <-s.ranges; got != "" {
+					t.Fatalf("first request: Range = %q, want none", got)
+				}
+
+				if tt.changed != "" {
+					s.content, s.etag, want = tt.changed, `"v2"`, tt.changed
+				}
+			}
+
+			got, err := download(t, url, partials, tt.retries)
+			if err != nil {
+				t.Fatalf("Download(%q): unexpected error: %v", url, err)
+			}
+
+			if got != want {
+				t.Errorf("Download(%q): content differs from the served file", url)
+			}
+
+			for _, want := range tt.ranges {
+				if got := <-s.ranges; got != want {
+					t.Errorf("Range = %q, want %q", got, want)
+				}
+			}
+
+			files, err := os.ReadDir(partials)
+			if err != nil {
+				t.Fatalf("reading partial downloads: %v", err)
+			}
+
+			// Only the lock files are kept, as removing them would race with waiting downloads.
+			for _, f := range files {
+				if !strings.HasSuffix(f.Name(), ".lock") {
+					t.Errorf("partial download left behind: %q", f.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestDownloadResumeRetries(t *testing.T) {
+	t.Parallel()
+
+	const retries = 2
+
+	var gets atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	url := srv.URL + "/tool"
+
+	d := download.New(
+		download.WithContextTimeout(10*time.Second),
+		download.WithResume(folder.New(t.TempDir())),
+		download.WithMaxRetries(retries),
+		download.WithRetryWaits(time.Millisecond, time.Millisecond),
+	)
+
+	if _, err := d.Download(t.Context(), url, t.TempDir()); err == nil {
+		t.Fatalf("Download(%q): expected error, got nil", url)
+	}
+
+	// Resumable downloads are retried by resuming them only, not by the HTTP client as well.
+	if got := gets.Load(); got != retries+1 {
+		t.Errorf("attempts = %d, want %d", got, retries+1)
+	}
+}
+
+func TestDownloadMirror(t *testing.T) {
+	t.Parallel()
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "origin")
+	}))
+	t.Cleanup(origin.Close)
+
+	mirrorSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mirror-token" || r.Header.Get("Private-Token") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/broken") {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, "mirror")
+	}))
+	t.Cleanup(mirrorSrv.Close)
+
+	tests := []struct {
+		name     string
+		path     string
+		fallback bool
+		want     string
+		wantErr  bool
+	}{
+		{name: "served by the mirror", path: "/tool", want: "mirror"},
+		{name: "falls back to the origin", path: "/missing", fallback: true, want: "origin"},
+		{name: "fails without fallback", path: "/missing", wantErr: true},
+		{name: "fails on mirror errors other than not found", path: "/broken", fallback: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := download.New(
+				download.WithContextTimeout(10*time.Second),
+				download.WithMaxRetries(0),
+				download.WithMirrors(mirror.Rules{{
+					Prefix:   origin.URL,
+					Replace:  mirrorSrv.URL,
+					Token:    "mirror-token",
+					Fallback: tt.fallback,
+				}}),
+			)
+
+			url := origin.URL + tt.path
+
+			// The credentials for the origin must not be sent to the mirror.
+			f, err := d.Download(t.Context(), url, t.TempDir(), http.Header{"Private-Token": {"origin-token"}})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Download(%q): expected error, got nil", url)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Download(%q): unexpected error: %v", url, err)
+			}
+
+			got, err := os.ReadFile(f.Path())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("Download(%q) = %q, want %q", url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadPhases(t *testing.T) {
+	t.Parallel()
+
+	var archive bytes.Buffer
+
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+
+	const content = "#!/bin/sh\necho tool\n"
+
+	if err := tw.WriteHeader(&tar.Header{Name: "tool", Mode: 0o755, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := io.WriteString(tw, content); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := errors.Join(tw.Close(), gz.Close()); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256(archive.Bytes())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+
+	var (
+		mu     sync.Mutex
+		phases []progress.Phase
+	)
+
+	ctx := progress.WithPhaseListener(t.Context(), func(phase progress.Phase) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		phases = append(phases, phase)
+	})
+
+	d := download.New(
+		download.WithContextTimeout(10*time.Second),
+		download.WithChecksum("checksum=sha256:"+hex.EncodeToString(sum[:])),
+		download.WithProgress(progress.NewNoop()),
+	)
+
+	if _, err := d.Download(ctx, srv.URL+"/tool.tar.gz", t.TempDir()); err != nil {
+		t.Fatalf("Download(): unexpected error: %v", err)
+	}
+
+	want := []progress.Phase{progress.Downloading, progress.Verifying, progress.Extracting}
+
+	if !slices.Equal(phases, want) {
+		t.Errorf("Download() entered phases %v, want %v", phases, want)
+	}
+}
+
+// BenchmarkDownloadTransport downloads 100 tools from a TLS server, either sharing one transport across the downloads
+// or building one per download, as each new transport opens new connections and pays a TLS handshake.
+func BenchmarkDownloadTransport(b *testing.B) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "tool")
+	}))
+	b.Cleanup(srv.Close)
+
+	shared, ok := srv.Client().Transport.(*http.Transport)
+	if !ok {
+		b.Fatal("test server client has no *http.Transport")
+	}
+
+	const tools = 100
+
+	run := func(b *testing.B, perDownload bool) {
+		b.Helper()
+
+		dir := b.TempDir()
+
+		for b.Loop() {
+			for i := range tools {
+				transport := shared
+				if perDownload {
+					transport = shared.Clone()
+				}
+
+				d := download.New(download.WithTransport(transport), download.WithMaxRetries(0))
+
+				if _, err := d.Download(b.Context(), srv.URL+"/tool", filepath.Join(dir, strconv.Itoa(i))); err != nil {
+					b.Fatal(err)
+				}
+
+				if perDownload {
+					transport.CloseIdleConnections()
+				}
+			}
+		}
+	}
+
+	b.Run("shared", func(b *testing.B) { run(b, false) })
+	b.Run("per-download", func(b *testing.B) { run(b, true) })
+}
@@ -1,9 +1,14 @@
 package download
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/hashicorp/go-getter/v2"
+
+	"github.com/idelchi/godyl/pkg/credentials"
+	"github.com/idelchi/godyl/pkg/mirror"
+	"github.com/idelchi/godyl/pkg/path/folder"
 )
 
 // Option defines a functional option for configuring a Downloader.
@@ -65,3 +70,40 @@ func WithRetryWaits(minWait, maxWait time.Duration) Option {
 		d.retryWaitMax = maxWait
 	}
 }
+
+// WithTokens returns an option that sets the tokens to authenticate requests with,
+// looked up by the host of the URL to download.
+func WithTokens(tokens credentials.Tokens) Option {
+	return func(d *Downloader) {
+		d.tokens = tokens
+	}
+}
+
+// WithArchiveCache returns an option that serves and stores downloads verified against a checksum from the cache.
+func WithArchiveCache(archives *ArchiveCache) Option {
+	return func(d *Downloader) {
+		d.archives = archives
+	}
+}
+
+// WithResume returns an option that keeps partial downloads in dir, such that interrupted downloads are resumed.
+func WithResume(dir folder.Folder) Option {
+	return func(d *Downloader) {
+		d.partials = dir
+	}
+}
+
+// WithMirrors returns an option that rewrites the URLs to download from with the rules.
+func WithMirrors(rules mirror.Rules) Option {
+	return func(d *Downloader) {
+		d.mirrors = rules
+	}
+}
+
+// WithTransport returns an option that sends the requests with the transport,
+// which is cloned for downloads skipping TLS verification.
+func WithTransport(transport *http.Transport) Option {
+	return func(d *Downloader) {
+		d.transport = transport
+	}
+}
@@ -0,0 +1,71 @@
+package download
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/go-getter/v2"
+
+	"github.com/idelchi/godyl/pkg/download/progress"
+)
+
+// decompressors returns the decompressors of the go-getter, entering the extracting phase before decompressing.
+func decompressors(ctx context.Context) map[string]getter.Decompressor {
+	phased := make(map[string]getter.Decompressor, len(getter.Decompressors))
+
+	for extension, decompressor := range getter.Decompressors {
+		phased[extension] = &extracting{Decompressor: decompressor, ctx: ctx}
+	}
+
+	return phased
+}
+
+// extracting enters the extracting phase before decompressing.
+type extracting struct {
+	getter.Decompressor
+
+	ctx context.Context //nolint:containedctx // The go-getter does not pass the context to decompressors.
+}
+
+// Decompress enters the extracting phase and decompresses src to dst.
+func (e *extracting) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	progress.Enter(e.ctx, progress.Extracting)
+
+	return e.Decompressor.Decompress(dst, src, dir, umask)
+}
+
+// verifying enters the verifying phase once a transfer ends, as the go-getter verifies the checksum right after.
+type verifying struct {
+	getter.ProgressTracker
+
+	ctx context.Context //nolint:containedctx // The go-getter does not pass the context to progress trackers.
+}
+
+// TrackProgress tracks the transfer, entering the verifying phase once the stream is closed.
+func (v *verifying) TrackProgress(src string, currentSize, totalSize int64, stream io.ReadCloser) io.ReadCloser {
+	return &closeNotifier{
+		ReadCloser: v.ProgressTracker.TrackProgress(src, currentSize, totalSize, stream),
+		closed: func() {
+			progress.Enter(v.ctx, progress.Verifying)
+		},
+	}
+}
+
+// closeNotifier calls closed once the stream is closed.
+type closeNotifier struct {
+	io.ReadCloser
+
+	closed func()
+	once   sync.Once
+}
+
+// Close closes the stream and calls closed, once.
+func (c *closeNotifier) Close() error {
+	err := c.ReadCloser.Close()
+
+	c.once.Do(c.closed)
+
+	return err
+}
@@ -1,4 +1,6 @@
 // Package progress implements file transfer progress tracking with concurrent
 // progress bars (Tracker) and a no-op implementation (Noop) for when tracking
 // is disabled, both implementing the ProgressTracker interface.
+// Phases entered by operations are reported through the context with Enter,
+// and displayed per item with a PhaseRow.
 package progress
@@ -21,3 +21,8 @@ func (n *Noop) Wait() {}
 func (n *Noop) TrackProgress(_ string, _, _ int64, rc io.ReadCloser) io.ReadCloser {
 	return rc
 }
+
+// PhaseRow returns a nil row, displaying nothing.
+func (n *Noop) PhaseRow(string) *PhaseRow {
+	return nil
+}
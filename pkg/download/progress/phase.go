@@ -0,0 +1,119 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	gpp "github.com/jedib0t/go-pretty/v6/progress"
+)
+
+// Phase is a step in processing an item, such as a tool.
+type Phase string
+
+const (
+	// Resolving is the phase of resolving the version and the URL to download from.
+	Resolving Phase = "resolving"
+	// Downloading is the phase of transferring files.
+	Downloading Phase = "downloading"
+	// Verifying is the phase of verifying downloaded files against their checksum.
+	Verifying Phase = "verifying"
+	// Extracting is the phase of extracting downloaded archives.
+	Extracting Phase = "extracting"
+	// Building is the phase of building from source.
+	Building Phase = "building"
+	// RunningCommands is the phase of running post-installation commands.
+	RunningCommands Phase = "running commands"
+)
+
+// PhaseListener is notified when a phase is entered.
+type PhaseListener func(phase Phase)
+
+// phaseListenerKey is the context key of the phase listener.
+type phaseListenerKey struct{}
+
+// WithPhaseListener returns a context notifying the listener of the phases entered by operations using it.
+func WithPhaseListener(ctx context.Context, listener PhaseListener) context.Context {
+	return context.WithValue(ctx, phaseListenerKey{}, listener)
+}
+
+// Enter notifies the phase listener of the context, if any, that the phase was entered.
+func Enter(ctx context.Context, phase Phase) {
+	if listener, ok := ctx.Value(phaseListenerKey{}).(PhaseListener); ok && listener != nil {
+		listener(phase)
+	}
+}
+
+// PhaseRow displays the phase an item is in, with the time spent in it.
+// A nil PhaseRow displays nothing.
+type PhaseRow struct {
+	since   time.Time
+	pw      gpp.Writer
+	tracker *gpp.Tracker
+	name    string
+	mu      sync.Mutex
+}
+
+// PhaseRow returns a row displaying the phases of the named item, shown once the first phase is entered.
+func (pt *Tracker) PhaseRow(name string) *PhaseRow {
+	return &PhaseRow{pw: pt.pw, name: name}
+}
+
+// Enter displays the phase as the current one, restarting the elapsed time.
+func (r *PhaseRow) Enter(phase Phase) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.since = time.Now()
+
+	message := fmt.Sprintf("%-45s (%s)", r.name, phase)
+
+	if r.tracker != nil {
+		r.tracker.UpdateMessage(message)
+
+		return
+	}
+
+	// The tracker is never started, such that only the elapsed time is rendered as its value, without a speed.
+	r.tracker = &gpp.Tracker{
+		Message:            message,
+		DeferStart:         true,
+		RemoveOnCompletion: true,
+		Units: gpp.Units{
+			Formatter: func(int64) string {
+				return r.elapsed().String()
+			},
+		},
+	}
+
+	r.pw.AppendTracker(r.tracker)
+}
+
+// Done removes the row.
+func (r *PhaseRow) Done() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tracker != nil {
+		r.tracker.MarkAsDone()
+	}
+}
+
+// elapsed returns the time spent in the current phase.
+func (r *PhaseRow) elapsed() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	const precision = 100 * time.Millisecond
+
+	return time.Since(r.since).Round(precision)
+}
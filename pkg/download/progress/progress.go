@@ -20,6 +20,7 @@ type ProgressTracker interface {
 	Start()
 	Wait()
 	TrackProgress(src string, currentSize, totalSize int64, stream io.ReadCloser) io.ReadCloser
+	PhaseRow(name string) *PhaseRow
 }
 
 // readCloserWithProgress wraps a reader to update a progress tracker as bytes are read.
@@ -140,8 +141,9 @@ func (pt *Tracker) TrackProgress(
 
 	srcPretty := file.New(src).Unescape()
 
+	// A resumed download after a failed attempt gets a new tracker, continuing from the current size.
 	tracker, ok := pt.trackers[src]
-	if !ok { //nolint:nestif,gocognit // Includes the timeout tracking logic
+	if !ok || tracker.IsDone() { //nolint:nestif,gocognit // Includes the timeout tracking logic
 		var sizeStr string
 
 		if totalSize >= 0 {
@@ -193,17 +195,19 @@ func (pt *Tracker) TrackProgress(
 			}()
 
 			return &readCloserWithProgress{
-				Reader:  stream,
-				Closer:  &closeWrapper{stream, pt.wg, tracker, stopTime},
-				Tracker: tracker,
+				Reader:    stream,
+				Closer:    &closeWrapper{stream, pt.wg, tracker, stopTime},
+				Tracker:   tracker,
+				totalRead: currentSize,
 			}
 		}
 	}
 
 	return &readCloserWithProgress{
-		Reader:  stream,
-		Closer:  &closeWrapper{stream, pt.wg, tracker, nil},
-		Tracker: tracker,
+		Reader:    stream,
+		Closer:    &closeWrapper{stream, pt.wg, tracker, nil},
+		Tracker:   tracker,
+		totalRead: currentSize,
 	}
 }
 
@@ -0,0 +1,308 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-getter/v2"
+
+	"github.com/idelchi/godyl/internal/debug"
+	"github.com/idelchi/godyl/pkg/flock"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+// partialMaxAge is the age after which abandoned partial downloads are removed.
+const partialMaxAge = 24 * time.Hour
+
+// partial describes the remote file a partial download belongs to.
+// A partial download is only resumed if the remote file still matches it.
+type partial struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Size         int64  `json:"size"`
+}
+
+// resumable reports whether the remote file can be validated to resume a download of it.
+func (p partial) resumable() bool {
+	return p.Size > 0 && (p.ETag != "" || p.LastModified != "")
+}
+
+// matches reports whether the partial download belongs to the same version of the remote file.
+func (p partial) matches(remote partial) bool {
+	if p.URL != remote.URL || p.Size != remote.Size {
+		return false
+	}
+
+	if remote.ETag != "" {
+		return p.ETag == remote.ETag
+	}
+
+	return p.LastModified == remote.LastModified
+}
+
+// ifRange returns the validator to make range requests conditional on, preferring strong ETags.
+func (p partial) ifRange() string {
+	if p.ETag != "" && !strings.HasPrefix(p.ETag, "W/") {
+		return p.ETag
+	}
+
+	return p.LastModified
+}
+
+// resumableGetter fetches files over HTTP into partial files kept across attempts and runs,
+// continuing interrupted downloads with range requests instead of starting over.
+type resumableGetter struct {
+	*getter.HttpGetter
+
+	dir          folder.Folder
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+}
+
+// resumable returns a getter resuming the downloads of g with the partial files of the downloader.
+func (d Downloader) resumable(g *getter.HttpGetter) *resumableGetter {
+	return &resumableGetter{
+		HttpGetter:   g,
+		dir:          d.partials,
+		maxRetries:   d.maxRetries,
+		retryWaitMin: d.retryWaitMin,
+		retryWaitMax: d.retryWaitMax,
+	}
+}
+
+// GetFile downloads the file of the request into its partial file, and moves it to the destination once complete.
+// Failed attempts are retried from where they stopped, with an exponential back-off.
+// The partial file is kept on failure, such that a later download can resume it.
+func (g *resumableGetter) GetFile(ctx context.Context, req *getter.Request) error {
+	// Leave out credentials, which are added to the URL from the netrc file.
+	u := *req.URL()
+	u.User = nil
+
+	src := u.String()
+	sum := sha256.Sum256([]byte(src))
+	key := hex.EncodeToString(sum[:])
+
+	data := g.dir.WithFile(key + ".part")
+	meta := g.dir.WithFile(key + ".json")
+
+	// Partial downloads may hold private data, so keep them to the user.
+	if err := g.dir.Create(0o700); err != nil {
+		return fmt.Errorf("creating %q: %w", g.dir, err)
+	}
+
+	// Serialize the downloads into the same partial file, within and across processes.
+	lock := flock.New(g.dir.WithFile(key + lockSuffix))
+	if err := lock.Lock(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			debug.Debug("unlocking %q: %v", lock.Path(), err)
+		}
+	}()
+
+	g.prune(key)
+
+	wait := g.retryWaitMin
+
+	for attempt := 0; ; attempt++ {
+		err := g.attempt(ctx, req, src, data, meta)
+		if err == nil {
+			break
+		}
+
+		if ctx.Err() != nil || attempt >= g.maxRetries {
+			return err
+		}
+
+		debug.Debug("resuming %q in %s after: %v", src, wait, err)
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+
+		wait = min(wait*2, g.retryWaitMax) //nolint:mnd // Doubling is the back-off.
+	}
+
+	if err := moveFile(data, file.New(req.Dst)); err != nil {
+		return err
+	}
+
+	if err := meta.Remove(); err != nil {
+		debug.Debug("removing %q: %v", meta, err)
+	}
+
+	return nil
+}
+
+// attempt downloads the remainder of the file into the partial file.
+// The partial file is discarded if the remote file changed since it was started, or can't be validated.
+func (g *resumableGetter) attempt(ctx context.Context, req *getter.Request, src string, data, meta file.File) error {
+	remote := g.head(ctx, src)
+
+	var stored partial
+
+	if content, err := meta.Read(); err == nil {
+		if err := json.Unmarshal(content, &stored); err != nil {
+			debug.Debug("reading %q: %v", meta, err)
+		}
+	}
+
+	// The go-getter resumes from the size of the file, so start over unless it belongs to the same remote file.
+	if !remote.resumable() || !stored.matches(remote) {
+		if err := errors.Join(data.Remove(), meta.Remove()); err != nil {
+			return fmt.Errorf("discarding partial download: %w", err)
+		}
+	}
+
+	hg := *g.HttpGetter
+
+	if remote.resumable() {
+		content, err := json.Marshal(remote)
+		if err != nil {
+			return err
+		}
+
+		if err := meta.Write(content, 0o600); err != nil {
+			return fmt.Errorf("writing %q: %w", meta, err)
+		}
+
+		// A changed file is then sent as a whole instead of the requested range.
+		if validator := remote.ifRange(); validator != "" {
+			hg.Header = hg.Header.Clone()
+			if hg.Header == nil {
+				hg.Header = make(http.Header)
+			}
+
+			hg.Header.Set("If-Range", validator)
+		}
+
+		if size, err := data.Size(); err == nil && size > 0 {
+			debug.Debug("resuming %q from %d of %d bytes", src, size, remote.Size)
+		}
+	}
+
+	attempt := *req
+	attempt.Dst = data.Path()
+
+	if err := hg.GetFile(ctx, &attempt); err != nil {
+		return err
+	}
+
+	if !remote.resumable() {
+		return nil
+	}
+
+	// A full response to a range request is appended to the partial file, so the size reveals it.
+	if size, err := data.Size(); err != nil || size != remote.Size {
+		if err := errors.Join(data.Remove(), meta.Remove()); err != nil {
+			debug.Debug("discarding partial download: %v", err)
+		}
+
+		return fmt.Errorf("downloaded %d of %d bytes of %q", size, remote.Size, src)
+	}
+
+	return nil
+}
+
+// head returns the description of the remote file, which is not resumable if it can't be determined.
+func (g *resumableGetter) head(ctx context.Context, src string) partial {
+	if g.HeadFirstTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, g.HeadFirstTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, src, nil)
+	if err != nil {
+		return partial{}
+	}
+
+	if g.Header != nil {
+		req.Header = g.Header.Clone()
+	}
+
+	client := g.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		debug.Debug("checking %q for resuming: %v", src, err)
+
+		return partial{}
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Accept-Ranges") != "bytes" {
+		return partial{}
+	}
+
+	return partial{
+		URL:          src,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Size:         resp.ContentLength,
+	}
+}
+
+// prune removes partial downloads abandoned for longer than the maximum age, except for the one with the key.
+func (g *resumableGetter) prune(key string) {
+	files, err := g.dir.ListFiles()
+	if err != nil {
+		return
+	}
+
+	for _, f := range files {
+		other, _, _ := strings.Cut(f.Base(), ".")
+		if other == key {
+			continue
+		}
+
+		info, err := f.Info()
+		if err != nil || time.Since(info.ModTime()) < partialMaxAge {
+			continue
+		}
+
+		// Leave the partial downloads in progress elsewhere alone.
+		lock := flock.New(g.dir.WithFile(other + lockSuffix))
+		if err := lock.TryLock(); err != nil {
+			continue
+		}
+
+		debug.Debug("removing abandoned partial download %q", f)
+
+		if err := errors.Join(f.Remove(), lock.Unlock()); err != nil {
+			debug.Debug("removing %q: %v", f, err)
+		}
+	}
+}
+
+// moveFile moves src to dst, copying it if the two are on different file systems.
+func moveFile(src, dst file.File) error {
+	if err := src.Rename(dst); err == nil {
+		return nil
+	}
+
+	if err := src.Copy(dst); err != nil {
+		return fmt.Errorf("moving %q to %q: %w", src, dst, err)
+	}
+
+	return src.Remove()
+}
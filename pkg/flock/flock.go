@@ -0,0 +1,107 @@
+// Package flock provides advisory file locks, held across processes.
+//
+// The locks are exclusive per open file, such that two locks on the same path exclude each other
+// whether they are held by the same or different processes. A lock is released by the operating
+// system once the process holding it exits, so a crashed process never leaves a stale lock behind.
+package flock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+// ErrLocked is returned by TryLock when the lock is held elsewhere.
+var ErrLocked = errors.New("locked")
+
+// retry is the interval at which a held lock is polled.
+const retry = 50 * time.Millisecond
+
+// Lock is an advisory lock on a file.
+type Lock struct {
+	file.File
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// New returns a lock on the file, which is created when the lock is first acquired.
+func New(file file.File) *Lock {
+	return &Lock{File: file}
+}
+
+// Lock acquires the lock, waiting until it is released elsewhere or the context is done.
+func (l *Lock) Lock(ctx context.Context) error {
+	ticker := time.NewTicker(retry)
+	defer ticker.Stop()
+
+	for {
+		err := l.TryLock()
+		if !errors.Is(err, ErrLocked) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for lock %q: %w", l.Path(), context.Cause(ctx))
+		case <-ticker.C:
+		}
+	}
+}
+
+// TryLock acquires the lock without waiting, returning ErrLocked if it is held elsewhere.
+func (l *Lock) TryLock() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.f != nil {
+		return fmt.Errorf("lock %q: already acquired", l.Path())
+	}
+
+	if err := os.MkdirAll(l.Dir(), 0o755); err != nil { //nolint:mnd	// Default directory permissions.
+		return fmt.Errorf("creating directory for lock %q: %w", l.Path(), err)
+	}
+
+	f, err := os.OpenFile(l.Path(), os.O_RDWR|os.O_CREATE, 0o600) //nolint:mnd	// Default file permissions.
+	if err != nil {
+		return fmt.Errorf("opening lock %q: %w", l.Path(), err)
+	}
+
+	if err := lock(f); err != nil {
+		f.Close()
+
+		if errors.Is(err, ErrLocked) {
+			return ErrLocked
+		}
+
+		return fmt.Errorf("acquiring lock %q: %w", l.Path(), err)
+	}
+
+	l.f = f
+
+	return nil
+}
+
+// Unlock releases the lock. Releasing a lock that is not held is a no-op.
+func (l *Lock) Unlock() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.f == nil {
+		return nil
+	}
+
+	f := l.f
+	l.f = nil
+
+	if err := unlock(f); err != nil {
+		return errors.Join(fmt.Errorf("releasing lock %q: %w", l.Path(), err), f.Close())
+	}
+
+	return f.Close() //nolint:wrapcheck	// Error does not need additional wrapping.
+}
@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package flock
+
+import "os"
+
+// lock is a no-op on platforms without file locking.
+func lock(*os.File) error {
+	return nil
+}
+
+// unlock is a no-op on platforms without file locking.
+func unlock(*os.File) error {
+	return nil
+}
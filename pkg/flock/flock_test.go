@@ -0,0 +1,55 @@
+package flock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/idelchi/godyl/pkg/flock"
+	"github.com/idelchi/godyl/pkg/path/file"
+)
+
+func TestLock(t *testing.T) {
+	t.Parallel()
+
+	path := file.New(t.TempDir(), "nested", "test.lock")
+
+	first := flock.New(path)
+	second := flock.New(path)
+
+	if err := first.TryLock(); err != nil {
+		t.Fatalf("TryLock() unexpected error: %v", err)
+	}
+
+	if err := second.TryLock(); !errors.Is(err, flock.ErrLocked) {
+		t.Fatalf("TryLock() on a held lock = %v, want %v", err, flock.ErrLocked)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := second.Lock(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Lock() on a held lock = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+
+		if err := first.Unlock(); err != nil {
+			t.Errorf("Unlock() unexpected error: %v", err)
+		}
+	}()
+
+	if err := second.Lock(t.Context()); err != nil {
+		t.Fatalf("Lock() after release unexpected error: %v", err)
+	}
+
+	if err := second.Unlock(); err != nil {
+		t.Fatalf("Unlock() unexpected error: %v", err)
+	}
+
+	if err := second.Unlock(); err != nil {
+		t.Fatalf("Unlock() of a released lock unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package flock
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// lock places an exclusive lock on the file, without waiting.
+func lock(f *os.File) error {
+	err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+	if errors.Is(err, unix.EWOULDBLOCK) {
+		return ErrLocked
+	}
+
+	return err //nolint:wrapcheck	// Error is wrapped by the caller.
+}
+
+// unlock removes the lock from the file.
+func unlock(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN) //nolint:wrapcheck	// Error is wrapped by the caller.
+}
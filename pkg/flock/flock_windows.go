@@ -0,0 +1,34 @@
+//go:build windows
+
+package flock
+
+import (
+	"errors"
+	"math"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lock places an exclusive lock on the file, without waiting.
+func lock(f *os.File) error {
+	err := windows.LockFileEx(
+		windows.Handle(f.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0,
+		math.MaxUint32,
+		math.MaxUint32,
+		&windows.Overlapped{},
+	)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return ErrLocked
+	}
+
+	return err //nolint:wrapcheck	// Error is wrapped by the caller.
+}
+
+// unlock removes the lock from the file.
+func unlock(f *os.File) error {
+	//nolint:wrapcheck	// Error is wrapped by the caller.
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, math.MaxUint32, math.MaxUint32, &windows.Overlapped{})
+}
@@ -0,0 +1,244 @@
+// Package httpcache provides an HTTP transport caching successful GET responses on disk.
+// Cached responses are served as-is until they expire, after which they are revalidated
+// with conditional requests using their ETag and Last-Modified headers.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/idelchi/godyl/internal/debug"
+	"github.com/idelchi/godyl/pkg/path/file"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+// credentialHeaders are the headers distinguishing the responses of the same URL for different credentials.
+//
+//nolint:gochecknoglobals	// Constant list of headers.
+var credentialHeaders = []string{"Authorization", "Private-Token", "Job-Token"}
+
+// volatileHeaders are the headers (by prefix) that describe a single response and are not stored.
+//
+//nolint:gochecknoglobals	// Constant list of headers.
+var volatileHeaders = []string{"Date", "Retry-After", "Set-Cookie", "X-Ratelimit-", "Ratelimit-"}
+
+// Cache stores responses as files in a directory.
+type Cache struct {
+	dir folder.Folder
+	ttl time.Duration
+}
+
+// New returns a Cache storing responses in dir, serving them without revalidation for ttl.
+func New(dir folder.Folder, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl}
+}
+
+// Transport wraps base, caching the responses of the requests made through it.
+// A nil base uses http.DefaultTransport.
+func (c *Cache) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{cache: c, base: base}
+}
+
+// entry is a stored response.
+type entry struct {
+	Stored time.Time   `json:"stored"`
+	Header http.Header `json:"header"`
+	URL    string      `json:"url"`
+	Body   []byte      `json:"body"`
+}
+
+// response recreates the stored response for the request.
+func (e *entry) response(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	header.Set("Content-Length", strconv.Itoa(len(e.Body)))
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// Key returns the key the response to the request is stored under.
+// Requests for the same URL with different representations or credentials are stored separately.
+func Key(req *http.Request) string {
+	h := sha256.New()
+
+	fmt.Fprintln(h, req.URL.String())
+	fmt.Fprintln(h, req.Header.Get("Accept"))
+
+	for _, name := range credentialHeaders {
+		fmt.Fprintln(h, req.Header.Get(name))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// file returns the file for the key.
+func (c *Cache) file(key string) file.File {
+	return c.dir.WithFile(key + ".json")
+}
+
+// load returns the entry stored under the key.
+func (c *Cache) load(key string) (*entry, bool) {
+	data, err := c.file(key).Read()
+	if err != nil {
+		return nil, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		debug.Debug("discarding corrupt cache entry %q: %v", key, err)
+
+		return nil, false
+	}
+
+	return &e, true
+}
+
+// store saves the entry under the key, replacing the previous one atomically.
+func (c *Cache) store(key string, e *entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encoding cache entry: %w", err)
+	}
+
+	// Responses may hold private data, so keep them to the user.
+	if err := c.dir.Create(0o700); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	tmp, err := file.CreateRandomInDir(c.dir.Path(), key+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	if err := tmp.Write(data); err != nil {
+		return errors.Join(err, tmp.Remove())
+	}
+
+	if err := tmp.Rename(c.file(key)); err != nil {
+		return errors.Join(err, tmp.Remove())
+	}
+
+	return nil
+}
+
+// transport is the http.RoundTripper serving and storing the responses.
+type transport struct {
+	cache *Cache
+	base  http.RoundTripper
+}
+
+// RoundTrip serves fresh responses from the cache, revalidates expired ones and stores new ones.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	key := Key(req)
+
+	cached, ok := t.cache.load(key)
+	if ok && time.Since(cached.Stored) < t.cache.ttl {
+		debug.Debug("serving %q from cache", req.URL)
+
+		return cached.response(req), nil
+	}
+
+	outgoing := req
+
+	if ok && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+		outgoing = req.Clone(req.Context())
+
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			outgoing.Header.Set("If-None-Match", etag)
+		}
+
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			outgoing.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case ok && resp.StatusCode == http.StatusNotModified:
+		debug.Debug("revalidated %q from cache", req.URL)
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		cached.Stored = time.Now()
+
+		if err := t.cache.store(key, cached); err != nil {
+			debug.Debug("storing %q in cache: %v", req.URL, err)
+		}
+
+		return cached.response(req), nil
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("reading response body: %w", err)
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err := t.cache.store(key, &entry{
+			Stored: time.Now(),
+			Header: stable(resp.Header),
+			URL:    req.URL.String(),
+			Body:   body,
+		}); err != nil {
+			debug.Debug("storing %q in cache: %v", req.URL, err)
+		}
+	}
+
+	return resp, nil
+}
+
+// stable returns the headers worth storing with a response.
+func stable(header http.Header) http.Header {
+	stored := make(http.Header, len(header))
+
+	for name, values := range header {
+		volatile := false
+
+		for _, prefix := range volatileHeaders {
+			if strings.HasPrefix(name, prefix) {
+				volatile = true
+
+				break
+			}
+		}
+
+		if !volatile {
+			stored[name] = values
+		}
+	}
+
+	return stored
+}
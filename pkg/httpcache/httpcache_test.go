@@ -0,0 +1,119 @@
+package httpcache_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/idelchi/godyl/pkg/httpcache"
+	"github.com/idelchi/godyl/pkg/path/folder"
+)
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		ttl          time.Duration
+		wantRequests int32
+		wantModified int32
+	}{
+		{
+			name:         "fresh responses are served from the cache",
+			ttl:          time.Hour,
+			wantRequests: 1,
+		},
+		{
+			name:         "expired responses are revalidated",
+			ttl:          0,
+			wantRequests: 3,
+			wantModified: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests, notModified atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+
+				if r.Header.Get("If-None-Match") == `"v1"` {
+					notModified.Add(1)
+					w.WriteHeader(http.StatusNotModified)
+
+					return
+				}
+
+				w.Header().Set("ETag", `"v1"`)
+				w.Header().Set("X-RateLimit-Remaining", "59")
+				_, _ = io.WriteString(w, `{"tag_name":"v1.0.0"}`)
+			}))
+			t.Cleanup(srv.Close)
+
+			cache := httpcache.New(folder.New(t.TempDir()), tc.ttl)
+			client := &http.Client{Transport: cache.Transport(nil)}
+
+			for range 3 {
+				req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				resp, err := client.Do(req)
+				if err != nil {
+					t.Fatalf("Do() error = %v", err)
+				}
+
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if resp.StatusCode != http.StatusOK || string(body) != `{"tag_name":"v1.0.0"}` {
+					t.Errorf("Do() = %d %q, want %d %q", resp.StatusCode, body, http.StatusOK, `{"tag_name":"v1.0.0"}`)
+				}
+			}
+
+			if got := requests.Load(); got != tc.wantRequests {
+				t.Errorf("server requests = %d, want %d", got, tc.wantRequests)
+			}
+
+			if got := notModified.Load(); got != tc.wantModified {
+				t.Errorf("not modified responses = %d, want %d", got, tc.wantModified)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func(auth string) *http.Request {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://api.github.com/repos/o/r", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+
+		return req
+	}
+
+	if httpcache.Key(newRequest("")) == httpcache.Key(newRequest("Bearer token")) {
+		t.Error("Key() is equal for anonymous and authenticated requests")
+	}
+
+	if httpcache.Key(newRequest("Bearer a")) != httpcache.Key(newRequest("Bearer a")) {
+		t.Error("Key() differs for identical requests")
+	}
+}
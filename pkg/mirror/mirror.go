@@ -0,0 +1,151 @@
+// Package mirror rewrites URLs to the mirrors configured for them.
+//
+// A rule matches a URL either by a prefix, which is replaced as a whole,
+// or by a regular expression, whose replacement may refer to its submatches as `$1`, `${name}`, etc.
+// The first matching rule wins.
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Rule rewrites the URLs it matches to a mirror.
+type Rule struct {
+	// Prefix matches URLs starting with it.
+	Prefix string `json:"prefix" mapstructure:"prefix" yaml:"prefix"`
+	// Regex matches URLs with the regular expression.
+	Regex string `json:"regex" mapstructure:"regex" yaml:"regex"`
+	// Replace is the replacement of the matched prefix or regular expression.
+	Replace string `json:"replace" mapstructure:"replace" yaml:"replace"`
+	// Headers are sent with the requests to the mirror.
+	Headers map[string]string `json:"headers" mapstructure:"headers" yaml:"headers"`
+	// Token is sent as bearer token with the requests to the mirror.
+	Token string `json:"token" mapstructure:"token" mask:"fixed" yaml:"token"`
+	// Fallback downloads from the original URL when the file is not found on the mirror.
+	Fallback bool `json:"fallback" mapstructure:"fallback" yaml:"fallback"`
+
+	// re is the compiled regular expression, if compiled.
+	re *regexp.Regexp
+}
+
+// Validate checks that the rule matches by exactly one of a prefix or a regular expression,
+// and that the regular expression compiles.
+func (r Rule) Validate() error {
+	switch {
+	case r.Prefix == "" && r.Regex == "":
+		return errors.New("one of 'prefix' or 'regex' is required")
+	case r.Prefix != "" && r.Regex != "":
+		return errors.New("'prefix' and 'regex' are mutually exclusive")
+	case r.Replace == "":
+		return errors.New("'replace' is required")
+	}
+
+	if r.Regex != "" {
+		if _, err := regexp.Compile(r.Regex); err != nil {
+			return fmt.Errorf("compiling 'regex': %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Compile validates the rule and compiles its regular expression, such that it is not compiled again on rewriting.
+func (r *Rule) Compile() error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	if r.Regex != "" {
+		r.re = regexp.MustCompile(r.Regex)
+	}
+
+	return nil
+}
+
+// Rewrite returns the URL rewritten by the rule, and whether the rule matched it.
+// The regular expression of a rule that is not compiled is compiled on each call.
+func (r Rule) Rewrite(url string) (string, bool) {
+	if r.Prefix != "" {
+		rest, ok := strings.CutPrefix(url, r.Prefix)
+		if !ok {
+			return url, false
+		}
+
+		return r.Replace + rest, true
+	}
+
+	re := r.re
+	if re == nil {
+		var err error
+
+		if re, err = regexp.Compile(r.Regex); err != nil {
+			return url, false
+		}
+	}
+
+	if !re.MatchString(url) {
+		return url, false
+	}
+
+	return re.ReplaceAllString(url, r.Replace), true
+}
+
+// Header returns the headers to send with the requests to the mirror.
+// The token is sent as `Authorization` header, unless the headers set one.
+func (r Rule) Header() http.Header {
+	header := make(http.Header, len(r.Headers)+1)
+
+	for k, v := range r.Headers {
+		header.Set(k, v)
+	}
+
+	if r.Token != "" && header.Get("Authorization") == "" {
+		header.Set("Authorization", "Bearer "+r.Token)
+	}
+
+	return header
+}
+
+// Rules is an ordered collection of rules.
+type Rules []Rule
+
+// Validate checks all rules.
+func (r Rules) Validate() error {
+	for i, rule := range r {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("mirror rule #%d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// Compiled returns a copy of the rules with their regular expressions compiled, to rewrite many URLs with.
+func (r Rules) Compiled() (Rules, error) {
+	compiled := slices.Clone(r)
+
+	for i := range compiled {
+		if err := compiled[i].Compile(); err != nil {
+			return nil, fmt.Errorf("mirror rule #%d: %w", i+1, err)
+		}
+	}
+
+	return compiled, nil
+}
+
+// Rewrite returns the URL rewritten by the first matching rule, together with the rule.
+// The URL is returned as is if no rule matches.
+func (r Rules) Rewrite(url string) (string, Rule, bool) {
+	for _, rule := range r {
+		if rewritten, ok := rule.Rewrite(url); ok {
+			return rewritten, rule, true
+		}
+	}
+
+	return url, Rule{}, false
+}
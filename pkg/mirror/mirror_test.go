@@ -0,0 +1,145 @@
+package mirror_test
+
+import (
+	"testing"
+
+	"github.com/idelchi/godyl/pkg/mirror"
+)
+
+func TestRulesRewrite(t *testing.T) {
+	t.Parallel()
+
+	rules := mirror.Rules{
+		{
+			Prefix:  "https://github.com/",
+			Replace: "https://artifactory.example.com/github/",
+		},
+		{
+			Regex:   `^https://go\.dev/dl/(go[^/]+)$`,
+			Replace: "https://artifactory.example.com/go/$1",
+		},
+		{
+			Prefix:  "https://github.com/idelchi/",
+			Replace: "https://unreachable.example.com/",
+		},
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+		ok   bool
+	}{
+		{
+			name: "prefix",
+			url:  "https://github.com/idelchi/godyl/releases/download/v1.0.0/godyl_linux_amd64.tar.gz",
+			want: "https://artifactory.example.com/github/idelchi/godyl/releases/download/v1.0.0/godyl_linux_amd64.tar.gz",
+			ok:   true,
+		},
+		{
+			name: "regex with submatch",
+			url:  "https://go.dev/dl/go1.25.0.linux-amd64.tar.gz",
+			want: "https://artifactory.example.com/go/go1.25.0.linux-amd64.tar.gz",
+			ok:   true,
+		},
+		{
+			name: "no match",
+			url:  "https://gitlab.com/group/project/-/releases",
+			want: "https://gitlab.com/group/project/-/releases",
+		},
+		{
+			name: "prefix matches only at the start",
+			url:  "https://proxy.example.com/https://github.com/idelchi/godyl",
+			want: "https://proxy.example.com/https://github.com/idelchi/godyl",
+		},
+	}
+
+	compiled, err := rules.Compiled()
+	if err != nil {
+		t.Fatalf("Compiled() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, _, ok := rules.Rewrite(tt.url)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Rewrite(%q) = %q, %v, want %q, %v", tt.url, got, ok, tt.want, tt.ok)
+			}
+
+			got, _, ok = compiled.Rewrite(tt.url)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("compiled Rewrite(%q) = %q, %v, want %q, %v", tt.url, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRulesCompiled(t *testing.T) {
+	t.Parallel()
+
+	if _, err := (mirror.Rules{{Regex: "(", Replace: "c"}}).Compiled(); err == nil {
+		t.Error("Compiled() with an invalid regex succeeded")
+	}
+
+	rules := mirror.Rules{{Regex: "^https://(.*)$", Replace: "https://mirror/$1"}}
+
+	if _, err := rules.Compiled(); err != nil {
+		t.Fatalf("Compiled() error = %v", err)
+	}
+
+	// The rules themselves are left as they are.
+	if got, _, _ := rules.Rewrite("https://example.com/a"); got != "https://mirror/example.com/a" {
+		t.Errorf("Rewrite() = %q, want %q", got, "https://mirror/example.com/a")
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rule    mirror.Rule
+		wantErr bool
+	}{
+		{name: "prefix", rule: mirror.Rule{Prefix: "https://github.com/", Replace: "https://mirror/"}},
+		{name: "regex", rule: mirror.Rule{Regex: "^https://(.*)$", Replace: "https://mirror/$1"}},
+		{name: "neither", rule: mirror.Rule{Replace: "https://mirror/"}, wantErr: true},
+		{name: "both", rule: mirror.Rule{Prefix: "a", Regex: "b", Replace: "c"}, wantErr: true},
+		{name: "no replacement", rule: mirror.Rule{Prefix: "https://github.com/"}, wantErr: true},
+		{name: "invalid regex", rule: mirror.Rule{Regex: "(", Replace: "c"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleHeader(t *testing.T) {
+	t.Parallel()
+
+	rule := mirror.Rule{Token: "secret", Headers: map[string]string{"X-JFrog-Art-Api": "key"}}
+
+	header := rule.Header()
+
+	if got := header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+	}
+
+	if got := header.Get("X-JFrog-Art-Api"); got != "key" {
+		t.Errorf("X-JFrog-Art-Api = %q, want %q", got, "key")
+	}
+
+	rule.Headers["Authorization"] = "Basic abc"
+
+	if got := rule.Header().Get("Authorization"); got != "Basic abc" {
+		t.Errorf("Authorization = %q, want the configured header to take precedence", got)
+	}
+}
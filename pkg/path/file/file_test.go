@@ -1159,3 +1159,45 @@ func TestFileLinesEmpty(t *testing.T) {
 		t.Errorf("Lines() on empty file = %v, want %v", got, want)
 	}
 }
+
+func TestFileWriteAtomic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	f := file.New(dir, "nested", "atomic.json")
+
+	if err := f.WriteAtomic([]byte("old")); err != nil {
+		t.Fatalf("WriteAtomic() unexpected error: %v", err)
+	}
+
+	if err := f.WriteAtomic([]byte("new"), 0o644); err != nil {
+		t.Fatalf("WriteAtomic() unexpected error: %v", err)
+	}
+
+	got, err := f.Read()
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+
+	if string(got) != "new" {
+		t.Errorf("Read() = %q, want %q", got, "new")
+	}
+
+	info, err := os.Stat(f.Path())
+	if err != nil {
+		t.Fatalf("Stat() unexpected error: %v", err)
+	}
+
+	if got := info.Mode().Perm(); got != 0o644 {
+		t.Errorf("permissions = %o, want %o", got, 0o644)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "nested"))
+	if err != nil {
+		t.Fatalf("ReadDir() unexpected error: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("expected no temporary files to remain, got %d entries", len(entries))
+	}
+}
@@ -104,6 +104,63 @@ func (f File) Write(data []byte, perm ...fs.FileMode) (err error) {
 	return nil
 }
 
+// WriteAtomic stores binary data in the file, such that readers see either the previous or the new contents.
+// The data is written to a temporary file in the same directory, which then replaces the file.
+//
+// An optional permission mode can be provided (default 0o600).
+// If multiple values are given, only the first is used.
+func (f File) WriteAtomic(data []byte, perm ...fs.FileMode) (err error) {
+	const defaultPerm = 0o600
+
+	mode := fs.FileMode(defaultPerm)
+
+	if len(perm) > 0 {
+		mode = perm[0]
+	}
+
+	if err := f.createFolder(); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(f.Dir(), "."+f.Base()+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary file for %q: %w", f, err)
+	}
+
+	// Remove the temporary file unless it replaced the file.
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if rerr := os.Remove(tmp.Name()); rerr != nil {
+			err = errors.Join(err, fmt.Errorf("removing temporary file %q: %w", tmp.Name(), rerr))
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return errors.Join(fmt.Errorf("writing to file %q: %w", tmp.Name(), err), tmp.Close())
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return errors.Join(fmt.Errorf("syncing file %q: %w", tmp.Name(), err), tmp.Close())
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing file %q: %w", tmp.Name(), err)
+	}
+
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("changing permissions of file %q: %w", tmp.Name(), err)
+	}
+
+	if err := os.Rename(tmp.Name(), f.Path()); err != nil {
+		return fmt.Errorf("renaming file %q to %q: %w", tmp.Name(), f, err)
+	}
+
+	return nil
+}
+
 // Open opens the file for reading and returns a pointer to the os.File object.
 // The user must close the file after use.
 func (f File) Open() (*os.File, error) {
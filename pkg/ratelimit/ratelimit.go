@@ -0,0 +1,264 @@
+// Package ratelimit provides an HTTP transport that schedules requests according to
+// the rate limits reported by the server, throttling requests per host and credentials.
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/idelchi/godyl/internal/debug"
+)
+
+// Policies defining how to handle an exhausted rate limit.
+const (
+	// Wait waits for the rate limit to reset before sending further requests.
+	Wait = "wait"
+	// Fail fails the requests until the rate limit resets.
+	Fail = "fail"
+)
+
+// Concurrent requests allowed per host and credentials.
+const (
+	// anonymousConcurrency applies to unauthenticated requests, which have the lowest limits.
+	anonymousConcurrency = 1
+	// authenticatedConcurrency applies to authenticated requests, staying clear of secondary rate limits.
+	authenticatedConcurrency = 4
+)
+
+const (
+	// maxRetries limits how often a rate limited request is retried with the wait policy.
+	maxRetries = 3
+	// defaultWait is used when a rate limited response carries no hint on when to retry.
+	defaultWait = time.Minute
+)
+
+// ErrRateLimited is returned for requests that are rate limited with the fail policy.
+var ErrRateLimited = errors.New("rate limited")
+
+// Scheduler tracks the rate limits per host and credentials.
+// It is safe for concurrent use.
+type Scheduler struct {
+	limits map[string]*limit
+	policy string
+	mu     sync.Mutex
+}
+
+// limit holds the state of a single host and credentials combination.
+type limit struct {
+	// until holds requests back until the time the rate limit resets.
+	until time.Time
+	// slots limits the number of concurrent requests.
+	slots chan struct{}
+}
+
+// New returns a Scheduler using the given policy.
+func New(policy string) *Scheduler {
+	return &Scheduler{
+		limits: make(map[string]*limit),
+		policy: policy,
+	}
+}
+
+// SetPolicy changes the policy of the Scheduler.
+func (s *Scheduler) SetPolicy(policy string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policy = policy
+}
+
+// Transport wraps base, scheduling all requests made through it.
+// A nil base uses http.DefaultTransport.
+func (s *Scheduler) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{scheduler: s, base: base}
+}
+
+// limitFor returns the limit for the request, creating it if needed.
+func (s *Scheduler) limitFor(req *http.Request) (*limit, string) {
+	key := req.URL.Host
+
+	concurrency := anonymousConcurrency
+
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		key += "#" + hex.EncodeToString(sum[:4])
+
+		concurrency = authenticatedConcurrency
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.limits[key]
+	if !ok {
+		l = &limit{slots: make(chan struct{}, concurrency)}
+		s.limits[key] = l
+	}
+
+	return l, s.policy
+}
+
+// wait blocks until the rate limit has reset, or fails with the fail policy.
+func (s *Scheduler) wait(req *http.Request, l *limit, policy string) error {
+	s.mu.Lock()
+	until := l.until
+	s.mu.Unlock()
+
+	d := time.Until(until)
+	if d <= 0 {
+		return nil
+	}
+
+	if policy == Fail {
+		return fmt.Errorf("%w: %s until %s", ErrRateLimited, req.URL.Host, until.Format(time.TimeOnly))
+	}
+
+	debug.Debug("rate limited by %q, waiting %s", req.URL.Host, d.Round(time.Second))
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// observe records the rate limit reported by the response,
+// returning whether the request itself was rejected due to it.
+func (s *Scheduler) observe(l *limit, resp *http.Response) bool {
+	until, limited := Reset(resp, time.Now())
+	if until.IsZero() {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if until.After(l.until) {
+		l.until = until
+	}
+
+	return limited
+}
+
+// Reset returns the time the rate limit reported by the response resets,
+// and whether the response was rejected due to it.
+// It returns the zero time if the response does not exhaust a rate limit.
+func Reset(resp *http.Response, now time.Time) (until time.Time, limited bool) {
+	rejected := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusForbidden
+
+	// Secondary rate limits tell how long to back off.
+	if rejected {
+		if after := resp.Header.Get("Retry-After"); after != "" {
+			if seconds, err := strconv.Atoi(after); err == nil {
+				return now.Add(time.Duration(seconds) * time.Second), true
+			}
+
+			if date, err := http.ParseTime(after); err == nil {
+				return date, true
+			}
+		}
+	}
+
+	// Primary rate limits report the remaining requests and when they reset.
+	remaining := header(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if remaining == "0" {
+		if reset, err := strconv.ParseInt(header(resp.Header, "X-RateLimit-Reset", "RateLimit-Reset"), 10, 64); err == nil {
+			return time.Unix(reset, 0), rejected
+		}
+
+		return now.Add(defaultWait), rejected
+	}
+
+	// Without any rate limit information, only a too many requests response is rate limited,
+	// a forbidden one is an authorization failure.
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return now.Add(defaultWait), true
+	}
+
+	return time.Time{}, false
+}
+
+// header returns the first set value of the keys.
+func header(h http.Header, keys ...string) string {
+	for _, key := range keys {
+		if value := h.Get(key); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// transport is the http.RoundTripper scheduling the requests.
+type transport struct {
+	scheduler *Scheduler
+	base      http.RoundTripper
+}
+
+// RoundTrip sends the request once its host allows it,
+// retrying requests rejected by a rate limit with the wait policy.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l, policy := t.scheduler.limitFor(req)
+
+	select {
+	case l.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	defer func() { <-l.slots }()
+
+	for attempt := 0; ; attempt++ {
+		if err := t.scheduler.wait(req, l, policy); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !t.scheduler.observe(l, resp) {
+			return resp, nil
+		}
+
+		if policy == Fail {
+			_ = resp.Body.Close()
+
+			return nil, fmt.Errorf("%w: %s responded with %s", ErrRateLimited, req.URL.Host, resp.Status)
+		}
+
+		// Give up once retries are exhausted, or if the request can't be replayed.
+		if attempt >= maxRetries || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
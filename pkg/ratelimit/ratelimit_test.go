@@ -0,0 +1,170 @@
+package ratelimit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/idelchi/godyl/pkg/ratelimit"
+)
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	reset := now.Add(10 * time.Minute)
+
+	tests := []struct {
+		name        string
+		status      int
+		headers     map[string]string
+		wantUntil   time.Time
+		wantLimited bool
+	}{
+		{
+			name:   "remaining requests",
+			status: http.StatusOK,
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "10",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+			},
+		},
+		{
+			name:   "last request succeeds but holds back the next",
+			status: http.StatusOK,
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+			},
+			wantUntil: reset,
+		},
+		{
+			name:   "primary rate limit exceeded",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+			},
+			wantUntil:   reset,
+			wantLimited: true,
+		},
+		{
+			name:        "secondary rate limit with retry after",
+			status:      http.StatusForbidden,
+			headers:     map[string]string{"Retry-After": "30"},
+			wantUntil:   now.Add(30 * time.Second),
+			wantLimited: true,
+		},
+		{
+			name:   "gitlab style headers",
+			status: http.StatusTooManyRequests,
+			headers: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+			},
+			wantUntil:   reset,
+			wantLimited: true,
+		},
+		{
+			name:        "too many requests without hints",
+			status:      http.StatusTooManyRequests,
+			wantUntil:   now.Add(time.Minute),
+			wantLimited: true,
+		},
+		{
+			name:   "forbidden without rate limit information",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{StatusCode: tc.status, Header: make(http.Header)}
+			for k, v := range tc.headers {
+				resp.Header.Set(k, v)
+			}
+
+			until, limited := ratelimit.Reset(resp, now)
+			if !until.Equal(tc.wantUntil) {
+				t.Errorf("Reset() until = %v, want %v", until, tc.wantUntil)
+			}
+
+			if limited != tc.wantLimited {
+				t.Errorf("Reset() limited = %v, want %v", limited, tc.wantLimited)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		policy    string
+		wantErr   error
+		wantCalls int32
+	}{
+		{
+			name:      "wait retries after the secondary rate limit",
+			policy:    ratelimit.Wait,
+			wantCalls: 2,
+		},
+		{
+			name:      "fail returns an error",
+			policy:    ratelimit.Fail,
+			wantErr:   ratelimit.ErrRateLimited,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if calls.Add(1) == 1 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusForbidden)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			t.Cleanup(srv.Close)
+
+			client := &http.Client{Transport: ratelimit.New(tc.policy).Transport(nil)}
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := client.Do(req)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tc.wantErr)
+			}
+
+			if err == nil {
+				resp.Body.Close()
+
+				if resp.StatusCode != http.StatusOK {
+					t.Errorf("Do() status = %d, want %d", resp.StatusCode, http.StatusOK)
+				}
+			}
+
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Errorf("server calls = %d, want %d", got, tc.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package signature verifies detached signatures of files against PEM encoded public keys.
+//
+// Supported are ECDSA keys, with ASN.1 signatures over the SHA-256 digest of the content
+// (as created by `cosign sign-blob --key`), and Ed25519 keys, with signatures over the content itself.
+// Signatures may be given raw or base64 encoded.
+package signature
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalid is returned when the signature does not match the content and the public key.
+var ErrInvalid = errors.New("invalid signature")
+
+// Verify verifies the signature of the content against the PEM encoded public key.
+func Verify(key, content, signature []byte) error {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return errors.New("no PEM encoded public key found")
+	}
+
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("parsing public key: %w", err)
+	}
+
+	signature = decode(signature)
+
+	switch public := public.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(content)
+
+		if !ecdsa.VerifyASN1(public, digest[:], signature) {
+			return ErrInvalid
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(public, content, signature) {
+			return ErrInvalid
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", public)
+	}
+
+	return nil
+}
+
+// decode returns the signature decoded from base64, or as is if it is not base64 encoded.
+func decode(signature []byte) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil {
+		return signature
+	}
+
+	return decoded
+}
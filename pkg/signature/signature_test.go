@@ -0,0 +1,105 @@
+package signature_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/idelchi/godyl/pkg/signature"
+)
+
+// encode returns the public key of the signer as PEM.
+func encode(t *testing.T, public crypto.PublicKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("abc123  godyl_linux_amd64.tar.gz\n")
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := sha256.Sum256(content)
+
+	ecSignature, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edSignature := ed25519.Sign(edKey, content)
+
+	tests := []struct {
+		name      string
+		key       []byte
+		content   []byte
+		signature []byte
+		wantErr   error
+	}{
+		{
+			name:      "ecdsa base64 signature",
+			key:       encode(t, &ecKey.PublicKey),
+			content:   content,
+			signature: []byte(base64.StdEncoding.EncodeToString(ecSignature) + "\n"),
+		},
+		{
+			name:      "ed25519 raw signature",
+			key:       encode(t, edPublic),
+			content:   content,
+			signature: edSignature,
+		},
+		{
+			name:      "tampered content",
+			key:       encode(t, &ecKey.PublicKey),
+			content:   []byte("def456  godyl_linux_amd64.tar.gz\n"),
+			signature: ecSignature,
+			wantErr:   signature.ErrInvalid,
+		},
+		{
+			name:      "other key",
+			key:       encode(t, edPublic),
+			content:   content,
+			signature: ecSignature,
+			wantErr:   signature.ErrInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := signature.Verify(tt.key, tt.content, tt.signature)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := signature.Verify([]byte("not a key"), content, edSignature); err == nil {
+		t.Error("Verify() with an invalid key succeeded")
+	}
+}
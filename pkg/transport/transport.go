@@ -0,0 +1,196 @@
+// Package transport builds pooled HTTP transports trusting additional certificate authorities,
+// routing requests through explicitly configured proxies and retrying failed requests.
+//
+// A transport is meant to be shared, such that connections (and their TLS handshakes) are reused across requests.
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Options configures the TLS and proxy settings of a transport.
+type Options struct {
+	// CAFile is a PEM bundle of certificate authorities to trust in addition to the system ones.
+	CAFile string
+	// CADir is a directory of PEM files of certificate authorities to trust in addition to the system ones.
+	CADir string
+	// Proxy is the proxy for HTTP and HTTPS requests, taking precedence over the environment variables.
+	Proxy string
+	// NoProxy is a comma-separated list of hosts, domains and networks to exclude from proxying,
+	// taking precedence over the environment variables.
+	NoProxy string
+	// InsecureSkipVerify disables TLS verification.
+	InsecureSkipVerify bool
+	// MaxConnsPerHost limits the connections per host, 0 means unlimited.
+	MaxConnsPerHost int
+	// Timeout limits the time to connect, complete the TLS handshake and receive the response headers,
+	// but not the time to receive the body. 0 keeps the defaults.
+	Timeout time.Duration
+}
+
+// keepAlive is the interval of the keep-alive probes of the connections.
+const keepAlive = 30 * time.Second
+
+// New returns a pooled transport with the options applied, attempting HTTP/2 for HTTPS requests.
+func New(opts Options) (*http.Transport, error) {
+	t := cleanhttp.DefaultPooledTransport()
+
+	proxy, err := opts.proxy()
+	if err != nil {
+		return nil, err
+	}
+
+	t.Proxy = proxy
+
+	if o := opts.MaxConnsPerHost; o > 0 {
+		t.MaxConnsPerHost = o
+		t.MaxIdleConnsPerHost = o
+	}
+
+	if o := opts.Timeout; o > 0 {
+		t.DialContext = (&net.Dialer{Timeout: o, KeepAlive: keepAlive}).DialContext
+		t.TLSHandshakeTimeout = o
+		t.ResponseHeaderTimeout = o
+	}
+
+	pool, err := opts.pool()
+	if err != nil {
+		return nil, err
+	}
+
+	if pool != nil || opts.InsecureSkipVerify {
+		t.TLSClientConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			RootCAs:            pool,
+			InsecureSkipVerify: opts.InsecureSkipVerify, //nolint:gosec	// Only set if explicitly requested
+		}
+	}
+
+	return t, nil
+}
+
+// proxy returns the function selecting the proxy for a request,
+// taking the proxy settings not configured from the environment.
+func (o Options) proxy() (func(*http.Request) (*url.URL, error), error) {
+	cfg := httpproxy.FromEnvironment()
+
+	if o.Proxy != "" {
+		if _, err := url.Parse(o.Proxy); err != nil {
+			return nil, fmt.Errorf("parsing proxy %q: %w", o.Proxy, err)
+		}
+
+		cfg.HTTPProxy = o.Proxy
+		cfg.HTTPSProxy = o.Proxy
+	}
+
+	if o.NoProxy != "" {
+		cfg.NoProxy = o.NoProxy
+	}
+
+	proxyFunc := cfg.ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// pool returns the system certificate authorities together with the configured ones,
+// or nil if none are configured.
+func (o Options) pool() (*x509.CertPool, error) {
+	if o.CAFile == "" && o.CADir == "" {
+		return nil, nil //nolint:nilnil	// No pool means the system one.
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %w", err)
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %q", o.CAFile)
+		}
+	}
+
+	if o.CADir != "" {
+		if err := appendDir(pool, o.CADir); err != nil {
+			return nil, err
+		}
+	}
+
+	return pool, nil
+}
+
+// appendDir adds the certificates of all files in dir to the pool.
+// Files without certificates, such as the hash links of `c_rehash`, are skipped.
+func appendDir(pool *x509.CertPool, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading CA directory: %w", err)
+	}
+
+	var found bool
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		// Follow symbolic links, and skip directories
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading CA file: %w", err)
+		}
+
+		found = pool.AppendCertsFromPEM(pem) || found
+	}
+
+	if !found {
+		return fmt.Errorf("no certificates found in CA directory %q", dir)
+	}
+
+	return nil
+}
+
+// Retry wraps base to retry requests failing with connection errors or server errors up to retries times,
+// with an exponential back-off between waitMin and waitMax. The last response is returned once the retries are exhausted.
+// Exhausted rate limits (429) are not retried, as they are left to the caller to schedule.
+func Retry(base http.RoundTripper, retries int, waitMin, waitMax time.Duration) http.RoundTripper {
+	client := retryablehttp.NewClient()
+
+	client.Logger = nil // silence default logging
+	client.HTTPClient = &http.Client{Transport: base}
+	client.RetryMax = retries
+	client.RetryWaitMin = waitMin
+	client.RetryWaitMax = waitMax
+	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	client.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			return false, nil
+		}
+
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+
+	return &retryablehttp.RoundTripper{Client: client}
+}
@@ -0,0 +1,188 @@
+package transport_test
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/idelchi/godyl/pkg/transport"
+)
+
+func TestNewCA(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+
+	block := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, block, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Files without certificates are skipped in the directory.
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    transport.Options
+		wantErr bool
+	}{
+		{name: "system CAs only", opts: transport.Options{}, wantErr: true},
+		{name: "CA file", opts: transport.Options{CAFile: caFile}},
+		{name: "CA directory", opts: transport.Options{CADir: dir}},
+		{name: "insecure", opts: transport.Options{InsecureSkipVerify: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr, err := transport.New(tt.opts)
+			if err != nil {
+				t.Fatalf("New(): unexpected error: %v", err)
+			}
+
+			resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GET error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewInvalidCA(t *testing.T) {
+	t.Parallel()
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opts := range []transport.Options{
+		{CAFile: empty},
+		{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+		{CADir: t.TempDir()},
+	} {
+		if _, err := transport.New(opts); err == nil {
+			t.Errorf("New(%+v): expected error, got nil", opts)
+		}
+	}
+}
+
+func TestNewProxy(t *testing.T) {
+	t.Parallel()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A proxied request carries the absolute URL of its target.
+		_, _ = io.WriteString(w, r.URL.String())
+	}))
+	t.Cleanup(proxy.Close)
+
+	const target = "http://tools.example.invalid/file"
+
+	tests := []struct {
+		name    string
+		opts    transport.Options
+		want    string
+		wantErr bool
+	}{
+		{name: "proxied", opts: transport.Options{Proxy: proxy.URL}, want: target},
+		{
+			name:    "excluded from proxying",
+			opts:    transport.Options{Proxy: proxy.URL, NoProxy: ".example.invalid"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr, err := transport.New(tt.opts)
+			if err != nil {
+				t.Fatalf("New(): unexpected error: %v", err)
+			}
+
+			resp, err := (&http.Client{Transport: tr}).Get(target)
+			if tt.wantErr {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatalf("GET %q: expected the unresolvable host to fail without proxy", target)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GET %q: unexpected error: %v", target, err)
+			}
+
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.want {
+				t.Errorf("proxy received %q, want %q", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		statuses []int
+		retries  int
+		want     int
+		attempts int32
+	}{
+		{name: "recovers from server errors", statuses: []int{503, 502, 200}, retries: 3, want: 200, attempts: 3},
+		{name: "returns the last response once exhausted", statuses: []int{500, 500, 500}, retries: 1, want: 500, attempts: 2},
+		{name: "leaves rate limits to the caller", statuses: []int{429, 200}, retries: 3, want: 429, attempts: 1},
+		{name: "does not retry client errors", statuses: []int{404, 200}, retries: 3, want: 404, attempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.statuses[attempts.Add(1)-1])
+			}))
+			t.Cleanup(srv.Close)
+
+			client := &http.Client{Transport: transport.Retry(http.DefaultTransport, tt.retries, time.Millisecond, time.Millisecond)}
+
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Fatalf("GET: unexpected error: %v", err)
+			}
+
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.want || attempts.Load() != tt.attempts {
+				t.Errorf("GET = %d after %d attempts, want %d after %d", resp.StatusCode, attempts.Load(), tt.want, tt.attempts)
+			}
+		})
+	}
+}